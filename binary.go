@@ -0,0 +1,370 @@
+package soytrie
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"math"
+	"slices"
+)
+
+// Binary format (version 1):
+//
+//	header:  magic "SOYT", version byte
+//	body:    root node record
+//	trailer: CRC-32C of body, big endian uint32
+//
+// A node record is a flags byte (bit 0 set if valued),
+// the value if valued, a uvarint number of children,
+// then for each child its key followed by its node record.
+const (
+	binaryMagic   = "SOYT"
+	BinaryVersion = 1
+
+	binaryFlagValued = 1 << 0
+)
+
+var (
+	ErrBadMagic           = errors.New("soytrie: bad magic bytes")
+	ErrUnsupportedVersion = errors.New("soytrie: unsupported binary version")
+	ErrChecksum           = errors.New("soytrie: checksum mismatch")
+	ErrCorrupted          = errors.New("soytrie: corrupted input")
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// Codec encodes and decodes a key or value type to and from a stream
+type Codec[T any] struct {
+	Encode func(w io.Writer, v T) error
+	Decode func(r io.Reader) (T, error)
+}
+
+// stringReadAllThreshold is the longest string StringCodec
+// allocates up front for
+const stringReadAllThreshold = 1 << 16
+
+// StringCodec encodes strings as a uvarint length followed by the bytes
+func StringCodec() Codec[string] {
+	return Codec[string]{
+		Encode: func(w io.Writer, s string) error {
+			b := make([]byte, 0, binary.MaxVarintLen64+len(s))
+			b = binary.AppendUvarint(b, uint64(len(s)))
+			_, err := w.Write(append(b, s...))
+			return err
+		},
+		Decode: func(r io.Reader) (string, error) {
+			l, err := readUvarint(r)
+			if err != nil {
+				return "", err
+			}
+			if l > math.MaxInt64 {
+				return "", fmt.Errorf("%w: string length %d", ErrCorrupted, l)
+			}
+			if l <= stringReadAllThreshold {
+				b := make([]byte, l)
+				_, err := io.ReadFull(r, b)
+				return string(b), unexpectedEOF(err)
+			}
+			// Do not trust large lengths before the bytes actually arrive
+			b, err := io.ReadAll(io.LimitReader(r, int64(l)))
+			if err != nil {
+				return "", err
+			}
+			if uint64(len(b)) != l {
+				return "", io.ErrUnexpectedEOF
+			}
+			return string(b), nil
+		},
+	}
+}
+
+// FixedCodec encodes fixed-size types (see encoding/binary)
+// in little endian
+func FixedCodec[T any]() Codec[T] {
+	return Codec[T]{
+		Encode: func(w io.Writer, v T) error {
+			return binary.Write(w, binary.LittleEndian, v)
+		},
+		Decode: func(r io.Reader) (T, error) {
+			var v T
+			err := binary.Read(r, binary.LittleEndian, &v)
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return v, err
+		},
+	}
+}
+
+// Encoder writes tries to a stream in the binary format
+type Encoder[K comparable, V any] struct {
+	w     io.Writer
+	key   Codec[K]
+	value Codec[V]
+
+	scratch [binary.MaxVarintLen64]byte
+}
+
+func NewEncoder[K comparable, V any](w io.Writer, key Codec[K], value Codec[V]) *Encoder[K, V] {
+	return &Encoder[K, V]{w: w, key: key, value: value}
+}
+
+// Encode writes the trie rooted at root, including header and checksum
+func (e *Encoder[K, V]) Encode(root *Node[K, V]) error {
+	bw := bufio.NewWriter(e.w)
+	_, err := bw.WriteString(binaryMagic)
+	if err != nil {
+		return err
+	}
+	err = bw.WriteByte(BinaryVersion)
+	if err != nil {
+		return err
+	}
+
+	h := crc32.New(crcTable)
+	err = e.encodeTree(io.MultiWriter(bw, h), root)
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(bw, binary.BigEndian, h.Sum32())
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// encodeFrame is a node waiting to be written, with the key leading to it
+type encodeFrame[K comparable, V any] struct {
+	key  K
+	node *Node[K, V]
+}
+
+// encodeTree writes the node records of the trie in pre-order.
+// Like decodeTree, it keeps its own stack instead of recursing.
+func (e *Encoder[K, V]) encodeTree(w io.Writer, root *Node[K, V]) error {
+	err := e.encodeNode(w, root)
+	if err != nil {
+		return err
+	}
+	stack := e.pushChildren(nil, root)
+	for len(stack) != 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		err = e.key.Encode(w, f.key)
+		if err != nil {
+			return fmt.Errorf("encoding key %v: %w", f.key, err)
+		}
+		err = e.encodeNode(w, f.node)
+		if err != nil {
+			return err
+		}
+		stack = e.pushChildren(stack, f.node)
+	}
+	return nil
+}
+
+// pushChildren pushes node's children in reverse, so that they are popped in order
+func (e *Encoder[K, V]) pushChildren(stack []encodeFrame[K, V], node *Node[K, V]) []encodeFrame[K, V] {
+	start := len(stack)
+	for k, child := range node.children() {
+		stack = append(stack, encodeFrame[K, V]{key: k, node: child})
+	}
+	slices.Reverse(stack[start:])
+	return stack
+}
+
+// encodeNode writes a node's flags, value and number of children
+func (e *Encoder[K, V]) encodeNode(w io.Writer, node *Node[K, V]) error {
+	var flags byte
+	if node.Valued {
+		flags |= binaryFlagValued
+	}
+	e.scratch[0] = flags
+	_, err := w.Write(e.scratch[:1])
+	if err != nil {
+		return err
+	}
+	if node.Valued {
+		err = e.value.Encode(w, node.Value)
+		if err != nil {
+			return fmt.Errorf("encoding value: %w", err)
+		}
+	}
+
+	return e.writeUvarint(w, uint64(len(node.Children)))
+}
+
+// Decoder reads tries from a stream in the binary format.
+// The decoder may buffer and read past the end of the encoded trie.
+type Decoder[K comparable, V any] struct {
+	r     *bufio.Reader
+	key   Codec[K]
+	value Codec[V]
+}
+
+func NewDecoder[K comparable, V any](r io.Reader, key Codec[K], value Codec[V]) *Decoder[K, V] {
+	return &Decoder[K, V]{r: bufio.NewReader(r), key: key, value: value}
+}
+
+// Decode reads the next trie from the stream, verifying
+// its header and checksum
+func (d *Decoder[K, V]) Decode() (*Node[K, V], error) {
+	header := make([]byte, len(binaryMagic)+1)
+	_, err := io.ReadFull(d.r, header)
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	if string(header[:len(binaryMagic)]) != binaryMagic {
+		return nil, ErrBadMagic
+	}
+	if v := header[len(binaryMagic)]; v != BinaryVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, v)
+	}
+
+	cr := &crcReader{r: d.r, h: crc32.New(crcTable)}
+	root, err := d.decodeTree(cr)
+	if err != nil {
+		return nil, err
+	}
+
+	var sum uint32
+	err = binary.Read(d.r, binary.BigEndian, &sum)
+	if err != nil {
+		return nil, fmt.Errorf("reading checksum: %w", unexpectedEOF(err))
+	}
+	if sum != cr.h.Sum32() {
+		return nil, ErrChecksum
+	}
+	return root, nil
+}
+
+// decodeFrame is a node whose children are still being decoded
+type decodeFrame[K comparable, V any] struct {
+	node      *Node[K, V]
+	remaining uint64
+}
+
+// decodeTree decodes the node records of a whole trie. It keeps
+// its own stack instead of recursing, so that deep (or malicious)
+// inputs cannot grow the goroutine stack without bound.
+func (d *Decoder[K, V]) decodeTree(r *crcReader) (*Node[K, V], error) {
+	root, count, err := d.decodeNode(r)
+	if err != nil {
+		return nil, err
+	}
+	stack := []decodeFrame[K, V]{{node: root, remaining: count}}
+	for len(stack) != 0 {
+		top := &stack[len(stack)-1]
+		if top.remaining == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		top.remaining--
+
+		k, err := d.key.Decode(r)
+		if err != nil {
+			return nil, fmt.Errorf("decoding key: %w", unexpectedEOF(err))
+		}
+		child, count, err := d.decodeNode(r)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := top.node.GetOrInsertDirect(k, child); exists {
+			return nil, fmt.Errorf("%w: duplicate key %v", ErrCorrupted, k)
+		}
+		stack = append(stack, decodeFrame[K, V]{node: child, remaining: count})
+	}
+	return root, nil
+}
+
+// decodeNode decodes a node's flags and value,
+// and returns it with its number of children
+func (d *Decoder[K, V]) decodeNode(r *crcReader) (*Node[K, V], uint64, error) {
+	flags, err := r.ReadByte()
+	if err != nil {
+		return nil, 0, unexpectedEOF(err)
+	}
+	if flags&^binaryFlagValued != 0 {
+		return nil, 0, fmt.Errorf("%w: bad node flags %#x", ErrCorrupted, flags)
+	}
+
+	node := New[K, V]()
+	if flags&binaryFlagValued != 0 {
+		node.Valued = true
+		node.Value, err = d.value.Decode(r)
+		if err != nil {
+			return nil, 0, fmt.Errorf("decoding value: %w", unexpectedEOF(err))
+		}
+	}
+
+	count, err := readUvarint(r)
+	if err != nil {
+		return nil, 0, unexpectedEOF(err)
+	}
+	return node, count, nil
+}
+
+// crcReader hashes every byte read through it
+type crcReader struct {
+	r   *bufio.Reader
+	h   hash.Hash32
+	buf [1]byte
+}
+
+func (c *crcReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.h.Write(p[:n])
+	return n, err
+}
+
+func (c *crcReader) ReadByte() (byte, error) {
+	b, err := c.r.ReadByte()
+	if err == nil {
+		c.buf[0] = b
+		c.h.Write(c.buf[:])
+	}
+	return b, err
+}
+
+// writeUvarint writes x using e's scratch buffer, so that
+// encoding does not allocate for every node
+func (e *Encoder[K, V]) writeUvarint(w io.Writer, x uint64) error {
+	_, err := w.Write(e.scratch[:binary.PutUvarint(e.scratch[:], x)])
+	return err
+}
+
+func readUvarint(r io.Reader) (uint64, error) {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = &byteReader{r: r}
+	}
+	x, err := binary.ReadUvarint(br)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return 0, fmt.Errorf("%w: %w", ErrCorrupted, err)
+	}
+	return x, err
+}
+
+type byteReader struct {
+	r io.Reader
+}
+
+func (b *byteReader) ReadByte() (byte, error) {
+	var buf [1]byte
+	_, err := io.ReadFull(b.r, buf[:])
+	return buf[0], err
+}
+
+// unexpectedEOF converts io.EOF to io.ErrUnexpectedEOF,
+// since EOF in the middle of a trie means the input was truncated
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,184 @@
+package soytrie_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func TestBinary(t *testing.T) {
+	root := soytrie.New[string, int32]()
+	_ = root.Insert(0, "/src")
+	_ = root.Insert(1, "/src", "/testdata")
+	_ = root.Insert(7, "/src", "/testdata", "/race", "/7")
+	_ = root.Insert(-1, "/release", "/amd64", "/bin", "/foo")
+	_ = root.Insert(-2, "/release", "/aarch64", "/bin", "/foo")
+
+	encode := func(t *testing.T) []byte {
+		t.Helper()
+		buf := bytes.NewBuffer(nil)
+		enc := soytrie.NewEncoder(buf, soytrie.StringCodec(), soytrie.FixedCodec[int32]())
+		err := enc.Encode(root)
+		if err != nil {
+			t.Fatal("unexpected error", err)
+		}
+		return buf.Bytes()
+	}
+	decode := func(data []byte) (*soytrie.Node[string, int32], error) {
+		dec := soytrie.NewDecoder(bytes.NewReader(data), soytrie.StringCodec(), soytrie.FixedCodec[int32]())
+		return dec.Decode()
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		decoded, err := decode(encode(t))
+		if err != nil {
+			t.Fatal("unexpected error", err)
+		}
+		count := 0
+		for path, node := range root.All() {
+			other, ok := decoded.Get(path...)
+			if !ok {
+				t.Fatalf("missing path %v", path)
+			}
+			if other.Valued != node.Valued || other.Value != node.Value {
+				t.Fatalf("unexpected node %+v for path %v", other, path)
+			}
+			count++
+		}
+		for range decoded.All() {
+			count--
+		}
+		if count != 0 {
+			t.Fatal("unexpected extra nodes")
+		}
+	})
+
+	t.Run("multiple tries in one stream", func(t *testing.T) {
+		data := encode(t)
+		stream := append(append([]byte{}, data...), data...)
+		dec := soytrie.NewDecoder(bytes.NewReader(stream), soytrie.StringCodec(), soytrie.FixedCodec[int32]())
+		for i := range 2 {
+			_, err := dec.Decode()
+			if err != nil {
+				t.Fatalf("unexpected error for trie %d: %v", i, err)
+			}
+		}
+		if _, err := dec.Decode(); !errors.Is(err, io.EOF) {
+			t.Fatalf("unexpected error %v, expecting EOF", err)
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		data := encode(t)
+		for i := 1; i < len(data); i++ {
+			_, err := decode(data[:i])
+			if err == nil {
+				t.Fatalf("unexpected nil error for truncated length %d", i)
+			}
+			if !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Fatalf("unexpected error for truncated length %d: %v", i, err)
+			}
+		}
+	})
+
+	t.Run("corrupted", func(t *testing.T) {
+		data := encode(t)
+		for i := range data {
+			corrupted := append([]byte{}, data...)
+			corrupted[i] ^= 0x5a
+			_, err := decode(corrupted)
+			if err == nil {
+				t.Fatalf("unexpected nil error for corrupted byte %d", i)
+			}
+		}
+	})
+
+	t.Run("bad header", func(t *testing.T) {
+		data := encode(t)
+		badMagic := append([]byte("JSON"), data[4:]...)
+		if _, err := decode(badMagic); !errors.Is(err, soytrie.ErrBadMagic) {
+			t.Fatalf("unexpected error %v", err)
+		}
+		badVersion := append([]byte{}, data...)
+		badVersion[4] = soytrie.BinaryVersion + 1
+		if _, err := decode(badVersion); !errors.Is(err, soytrie.ErrUnsupportedVersion) {
+			t.Fatalf("unexpected error %v", err)
+		}
+	})
+}
+
+func TestBinaryDeep(t *testing.T) {
+	// A single chain, written by hand since building it
+	// through the Node API would be slow
+	const depth = 1_000_000
+	body := make([]byte, 0, depth*3+3)
+	for range depth {
+		body = append(body, 0, 1, 'k') // flags, 1 child, key
+	}
+	body = append(body, 1, 42, 0) // flags valued, value, no children
+
+	var buf bytes.Buffer
+	buf.WriteString("SOYT")
+	buf.WriteByte(soytrie.BinaryVersion)
+	buf.Write(body)
+	_ = binary.Write(&buf, binary.BigEndian, crc32.Checksum(body, crc32.MakeTable(crc32.Castagnoli)))
+	input := bytes.Clone(buf.Bytes())
+
+	codec := soytrie.FixedCodec[uint8]()
+	root, err := soytrie.NewDecoder(&buf, codec, codec).Decode()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	node := root
+	for range depth {
+		next, ok := node.GetDirect('k')
+		if !ok {
+			t.Fatal("unexpected missing child")
+		}
+		node = next
+	}
+	if !node.Valued || node.Value != 42 || len(node.Children) != 0 {
+		t.Fatalf("unexpected leaf %+v", node)
+	}
+
+	buf.Reset()
+	err = soytrie.NewEncoder(&buf, codec, codec).Encode(root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), input) {
+		t.Fatal("unexpected encoding of deep trie")
+	}
+}
+
+func TestBinaryAllocs(t *testing.T) {
+	root := soytrie.New[string, int32]()
+	for i := range 200 {
+		_ = root.Insert(int32(i), "/src", string(rune('a'+i%26)), string(rune('a'+i/26)))
+	}
+	nodes := 0
+	for range root.All() {
+		nodes++
+	}
+
+	var buf bytes.Buffer
+	err := soytrie.NewEncoder(&buf, soytrie.StringCodec(), soytrie.FixedCodec[int32]()).Encode(root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data := buf.Bytes()
+
+	// Decoding allocates each node, its children map, key and value,
+	// but nothing per byte read
+	allocs := testing.AllocsPerRun(10, func() {
+		_, _ = soytrie.NewDecoder(bytes.NewReader(data), soytrie.StringCodec(), soytrie.FixedCodec[int32]()).Decode()
+	})
+	if perNode := allocs / float64(nodes); perNode > 5 {
+		t.Fatalf("unexpected %.1f allocations per node", perNode)
+	}
+}
@@ -0,0 +1,54 @@
+package soytrie
+
+// Clone returns a deep copy of n. Values are copied by assignment,
+// so values holding pointers still share their pointees.
+func (n *Node[K, V]) Clone() *Node[K, V] {
+	return MapValues(n, func(_ []K, v V) V {
+		return v
+	})
+}
+
+// Equal returns whether n and other have the same shape,
+// including non-valued nodes, and equal values by eq.
+// Nil nodes are only equal to nil nodes.
+func (n *Node[K, V]) Equal(other *Node[K, V], eq func(V, V) bool) bool {
+	if n == nil || other == nil {
+		return n == other
+	}
+	if n.Valued != other.Valued {
+		return false
+	}
+	if n.Valued && !eq(n.Value, other.Value) {
+		return false
+	}
+	if len(n.Children) != len(other.Children) {
+		return false
+	}
+	for k, child := range n.Children {
+		otherChild, ok := other.GetDirect(k)
+		if !ok || !child.Equal(otherChild, eq) {
+			return false
+		}
+	}
+	return true
+}
+
+// MapValues returns a new trie with the same shape as n,
+// with each value mapped by f. Non-valued nodes stay non-valued.
+func MapValues[K comparable, V any, W any](n *Node[K, V], f func(path []K, v V) W) *Node[K, W] {
+	return mapValues(n, f, nil)
+}
+
+func mapValues[K comparable, V any, W any](n *Node[K, V], f func(path []K, v V) W, path []K) *Node[K, W] {
+	mapped := &Node[K, W]{Valued: n.Valued, order: n.order, seq: n.seq}
+	if n.Valued {
+		mapped.Value = f(path, n.Value)
+	}
+	if n.Children != nil {
+		mapped.Children = make(map[K]*Node[K, W], len(n.Children))
+	}
+	for k, child := range n.children() {
+		mapped.Children[k] = mapValues(child, f, append(path, k))
+	}
+	return mapped
+}
@@ -0,0 +1,124 @@
+package soytrie_test
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func TestClone(t *testing.T) {
+	eq := func(a, b string) bool { return a == b }
+	root := soytrie.NewWithValue[int]("root")
+	_ = root.Insert("1,2", 1, 2)
+	_ = root.Insert("1,2,3", 1, 2, 3)
+	_ = root.Insert("5,6,7", 5, 6, 7)
+
+	clone := root.Clone()
+	if !root.Equal(clone, eq) || !clone.Equal(root, eq) {
+		t.Fatal("unexpected unequal clone")
+	}
+
+	for path, node := range root.All() {
+		cloned, ok := clone.Get(path...)
+		if !ok || cloned == node {
+			t.Fatalf("unexpected shared node at path %v", path)
+		}
+	}
+
+	_ = clone.Insert("new", 1, 2)
+	if node, _ := root.Get(1, 2); node.Value != "1,2" {
+		t.Fatal("unexpected change to original after changing clone")
+	}
+	if root.Equal(clone, eq) {
+		t.Fatal("unexpected equal after modifying value")
+	}
+
+	t.Run("Equal compares shape", func(t *testing.T) {
+		other := root.Clone()
+		if !root.Equal(other, eq) {
+			t.Fatal("unexpected unequal clone")
+		}
+		// Delete prunes 5,6,8, restoring the original shape
+		_ = other.Insert("x", 5, 6, 8)
+		other.Delete(5, 6, 8)
+		if !root.Equal(other, eq) {
+			t.Fatal("unexpected unequal after insert and delete")
+		}
+		_, _ = other.GetOrInsertDirect(9, soytrie.New[int, string]())
+		if root.Equal(other, eq) {
+			t.Fatal("unexpected equal with extra non-valued node")
+		}
+
+		other = root.Clone()
+		other.Valued = false
+		if root.Equal(other, eq) {
+			t.Fatal("unexpected equal with different valued flag")
+		}
+	})
+
+	t.Run("Equal with nil", func(t *testing.T) {
+		if root.Equal(nil, eq) {
+			t.Fatal("unexpected equal to nil")
+		}
+		var nilNode *soytrie.Node[int, string]
+		if !nilNode.Equal(nil, eq) || nilNode.Equal(root, eq) {
+			t.Fatal("unexpected result for nil receiver")
+		}
+
+		// Nil child pointers in user-built maps
+		a := &soytrie.Node[int, string]{Children: map[int]*soytrie.Node[int, string]{1: nil}}
+		b := &soytrie.Node[int, string]{Children: map[int]*soytrie.Node[int, string]{1: nil}}
+		if !a.Equal(b, eq) {
+			t.Fatal("unexpected unequal with nil children")
+		}
+		b.Children[1] = soytrie.New[int, string]()
+		if a.Equal(b, eq) || b.Equal(a, eq) {
+			t.Fatal("unexpected equal with nil and non-nil children")
+		}
+	})
+
+	t.Run("MapValues", func(t *testing.T) {
+		mapped := soytrie.MapValues(root, func(path []int, v string) int {
+			return len(path)*100 + len(v)
+		})
+
+		if !mapped.Valued || mapped.Value != len("root") {
+			t.Fatalf("unexpected mapped root %+v", mapped)
+		}
+		for path, node := range root.All() {
+			m, ok := mapped.Get(path...)
+			if !ok {
+				t.Fatalf("missing path %v", path)
+			}
+			if m.Valued != node.Valued {
+				t.Fatalf("unexpected valued=%v at path %v", m.Valued, path)
+			}
+			if node.Valued && m.Value != len(path)*100+len(node.Value) {
+				t.Fatalf("unexpected mapped value %d at path %v", m.Value, path)
+			}
+			if !node.Valued && m.Value != 0 {
+				t.Fatalf("unexpected value %d for non-valued node", m.Value)
+			}
+		}
+
+		strs := soytrie.MapValues(mapped, func(_ []int, v int) string { return strconv.Itoa(v) })
+		count := 0
+		for range strs.All() {
+			count++
+		}
+		if count != 7 {
+			t.Fatalf("unexpected node count %d", count)
+		}
+
+		paths := [][]int{}
+		_ = soytrie.MapValues(root, func(path []int, _ string) struct{} {
+			paths = append(paths, slices.Clone(path))
+			return struct{}{}
+		})
+		if l := len(paths); l != 4 {
+			t.Fatalf("unexpected number of mapped values %d", l)
+		}
+	})
+}
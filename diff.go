@@ -0,0 +1,135 @@
+package soytrie
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ChangeKind is the kind of change to a path
+type ChangeKind uint8
+
+const (
+	ChangeAdded ChangeKind = iota
+	ChangeRemoved
+	ChangeModified
+)
+
+var changeKindNames = []string{"added", "removed", "modified"}
+
+func (c ChangeKind) String() string {
+	if int(c) < len(changeKindNames) {
+		return changeKindNames[c]
+	}
+	return fmt.Sprintf("ChangeKind(%d)", c)
+}
+
+func (c ChangeKind) MarshalText() ([]byte, error) {
+	if int(c) >= len(changeKindNames) {
+		return nil, fmt.Errorf("bad change kind %d", c)
+	}
+	return []byte(c.String()), nil
+}
+
+func (c *ChangeKind) UnmarshalText(text []byte) error {
+	i := slices.Index(changeKindNames, string(text))
+	if i == -1 {
+		return fmt.Errorf("bad change kind %q", text)
+	}
+	*c = ChangeKind(i)
+	return nil
+}
+
+// Change is a change to the value at Path.
+// Old is unset for added paths, and New is unset for removed paths.
+type Change[K comparable, V any] struct {
+	Kind ChangeKind `json:"kind"`
+	Path []K        `json:"path"`
+	Old  V          `json:"old,omitempty"`
+	New  V          `json:"new,omitempty"`
+}
+
+// Patch is a list of changes that turns one trie into another
+type Patch[K comparable, V any] []Change[K, V]
+
+// Diff returns the changes to valued paths that turn a into b.
+// Values are compared with eq. Non-valued nodes are not compared.
+func Diff[K comparable, V any](a, b *Node[K, V], eq func(V, V) bool) Patch[K, V] {
+	patch := Patch[K, V]{}
+	diff(a, b, eq, nil, &patch)
+	return patch
+}
+
+func diff[K comparable, V any](a, b *Node[K, V], eq func(V, V) bool, path []K, patch *Patch[K, V]) {
+	switch {
+	case a != nil && a.Valued && b != nil && b.Valued:
+		if !eq(a.Value, b.Value) {
+			*patch = append(*patch, Change[K, V]{Kind: ChangeModified, Path: slices.Clone(path), Old: a.Value, New: b.Value})
+		}
+	case a != nil && a.Valued:
+		*patch = append(*patch, Change[K, V]{Kind: ChangeRemoved, Path: slices.Clone(path), Old: a.Value})
+	case b != nil && b.Valued:
+		*patch = append(*patch, Change[K, V]{Kind: ChangeAdded, Path: slices.Clone(path), New: b.Value})
+	}
+
+	if a != nil {
+		for k, childA := range a.children() {
+			var childB *Node[K, V]
+			if b != nil {
+				childB = b.Children[k]
+			}
+			diff(childA, childB, eq, append(path, k), patch)
+		}
+	}
+	if b != nil {
+		for k, childB := range b.children() {
+			if a != nil && a.HasDirect(k) {
+				continue
+			}
+			diff(nil, childB, eq, append(path, k), patch)
+		}
+	}
+}
+
+// Apply applies patch to n. Every change is checked before n
+// is modified: added paths must not be valued, and removed or modified
+// paths must hold a value equal to Old by eq, so that a stale patch
+// does not overwrite other changes. Removed paths are deleted
+// with Delete, so empty nodes are pruned.
+func (n *Node[K, V]) Apply(patch Patch[K, V], eq func(V, V) bool) error {
+	for i := range patch {
+		c := &patch[i]
+		node, ok := n.Get(c.Path...)
+		valued := ok && node.Valued
+		switch c.Kind {
+		case ChangeAdded:
+			if valued {
+				return fmt.Errorf("change %d: added path %v is already valued", i, c.Path)
+			}
+		case ChangeRemoved, ChangeModified:
+			if !valued {
+				return fmt.Errorf("change %d: %s path %v is not valued", i, c.Kind, c.Path)
+			}
+			if !eq(node.Value, c.Old) {
+				return fmt.Errorf("change %d: %s path %v does not hold the old value", i, c.Kind, c.Path)
+			}
+		default:
+			return fmt.Errorf("change %d: bad change kind %d", i, c.Kind)
+		}
+	}
+
+	for i := range patch {
+		c := &patch[i]
+		switch c.Kind {
+		case ChangeAdded, ChangeModified:
+			if len(c.Path) == 0 {
+				n.Valued, n.Value = true, c.New
+				continue
+			}
+			_ = n.Insert(c.New, c.Path[0], c.Path[1:]...)
+
+		case ChangeRemoved:
+			_, _ = n.Delete(c.Path...)
+		}
+	}
+	return nil
+}
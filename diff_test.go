@@ -0,0 +1,133 @@
+package soytrie_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func TestDiff(t *testing.T) {
+	eq := func(a, b string) bool { return a == b }
+	build := func(entries map[string]string) *soytrie.Node[string, string] {
+		root := soytrie.New[string, string]()
+		for p, v := range entries {
+			if p == "" {
+				root.Valued, root.Value = true, v
+				continue
+			}
+			path := strings.Split(p, ".")
+			_ = root.Insert(v, path[0], path[1:]...)
+		}
+		return root
+	}
+
+	a := build(map[string]string{
+		"server.port":      "8080",
+		"server.host":      "localhost",
+		"db.url":           "postgres://a",
+		"db.pool.size":     "10",
+		"logging.level":    "info",
+		"logging.fmt.json": "true",
+	})
+	b := build(map[string]string{
+		"":                 "root",
+		"server.port":      "9090",
+		"server.host":      "localhost",
+		"db.url":           "postgres://a",
+		"db.pool":          "default",
+		"logging.level":    "debug",
+		"tracing.endpoint": "otel:4317",
+	})
+
+	patch := soytrie.Diff(a, b, eq)
+	kinds := map[string]soytrie.ChangeKind{}
+	for _, c := range patch {
+		kinds[strings.Join(c.Path, ".")] = c.Kind
+	}
+	expected := map[string]soytrie.ChangeKind{
+		"":                 soytrie.ChangeAdded,
+		"server.port":      soytrie.ChangeModified,
+		"db.pool":          soytrie.ChangeAdded,
+		"db.pool.size":     soytrie.ChangeRemoved,
+		"logging.level":    soytrie.ChangeModified,
+		"logging.fmt.json": soytrie.ChangeRemoved,
+		"tracing.endpoint": soytrie.ChangeAdded,
+	}
+	if len(kinds) != len(expected) || len(patch) != len(expected) {
+		t.Fatalf("unexpected changes %v", kinds)
+	}
+	for p, kind := range expected {
+		if actual, ok := kinds[p]; !ok || actual != kind {
+			t.Fatalf("unexpected change %v for path %s, expecting %v", actual, p, kind)
+		}
+	}
+
+	if l := len(soytrie.Diff(a, a, eq)); l != 0 {
+		t.Fatalf("unexpected %d changes between identical tries", l)
+	}
+
+	t.Run("apply serialized patch", func(t *testing.T) {
+		data, err := json.Marshal(patch)
+		if err != nil {
+			t.Fatal("unexpected error", err)
+		}
+		if !strings.Contains(string(data), `"kind":"modified"`) {
+			t.Fatalf("unexpected serialized patch %s", data)
+		}
+
+		var decoded soytrie.Patch[string, string]
+		err = json.Unmarshal(data, &decoded)
+		if err != nil {
+			t.Fatal("unexpected error", err)
+		}
+
+		err = a.Apply(decoded, eq)
+		if err != nil {
+			t.Fatal("unexpected error", err)
+		}
+		if l := len(soytrie.Diff(a, b, eq)); l != 0 {
+			t.Fatalf("unexpected %d changes after Apply", l)
+		}
+		if a.Search(soytrie.ModePrefix, "logging", "fmt") {
+			t.Fatal("unexpected dead prefix after Apply")
+		}
+	})
+
+	t.Run("apply checks every change first", func(t *testing.T) {
+		c := build(map[string]string{"a": "1"})
+		bad := soytrie.Patch[string, string]{
+			{Kind: soytrie.ChangeModified, Path: []string{"a"}, Old: "1", New: "2"},
+			{Kind: soytrie.ChangeRemoved, Path: []string{"b"}, Old: "x"},
+		}
+		if err := c.Apply(bad, eq); err == nil {
+			t.Fatal("unexpected nil error")
+		}
+		if node, _ := c.Get("a"); node.Value != "1" {
+			t.Fatal("unexpected partial Apply")
+		}
+
+		if err := c.Apply(soytrie.Patch[string, string]{{Kind: soytrie.ChangeAdded, Path: []string{"a"}}}, eq); err == nil {
+			t.Fatal("unexpected nil error")
+		}
+
+		// Stale patches are rejected
+		for _, stale := range []soytrie.Change[string, string]{
+			{Kind: soytrie.ChangeModified, Path: []string{"a"}, Old: "0", New: "2"},
+			{Kind: soytrie.ChangeRemoved, Path: []string{"a"}, Old: "0"},
+		} {
+			if err := c.Apply(soytrie.Patch[string, string]{stale}, eq); err == nil {
+				t.Fatalf("unexpected nil error for stale %s", stale.Kind)
+			}
+		}
+		if node, _ := c.Get("a"); node.Value != "1" {
+			t.Fatal("unexpected Apply of stale patch")
+		}
+
+		var kind soytrie.ChangeKind
+		if err := json.Unmarshal([]byte(`"renamed"`), &kind); err == nil {
+			t.Fatal("unexpected nil error")
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package soytrie
+
+// Filter returns a new trie with only the entries of n for which
+// pred returns true, and the number of entries left out.
+// See DeleteIf for how empty nodes are cleaned up.
+func (n *Node[K, V]) Filter(pred func(path []K, v V) bool) (*Node[K, V], int) {
+	filtered := n.Clone()
+	removed := filtered.Retain(pred)
+	return filtered, removed
+}
+
+// DeleteIf clears the values for which pred returns true,
+// and returns the number of values cleared. Nodes left with no value
+// and no children are pruned, but the receiver never is.
+//
+// Nodes that were already empty are left alone, like with Remove.
+func (n *Node[K, V]) DeleteIf(pred func(path []K, v V) bool) int {
+	removed, _ := deleteIf(n, pred, nil)
+	return removed
+}
+
+// Retain keeps only the values for which pred returns true,
+// and returns the number of values cleared. See DeleteIf.
+func (n *Node[K, V]) Retain(pred func(path []K, v V) bool) int {
+	return n.DeleteIf(func(path []K, v V) bool {
+		return !pred(path, v)
+	})
+}
+
+// deleteIf implements DeleteIf, and also returns whether node was changed
+func deleteIf[K comparable, V any](node *Node[K, V], pred func([]K, V) bool, path []K) (int, bool) {
+	removed, changed := 0, false
+	if node.Valued && pred(path, node.Value) {
+		var zero V
+		node.Valued, node.Value = false, zero
+		removed, changed = 1, true
+	}
+	for k, child := range node.children() {
+		r, c := deleteIf(child, pred, append(path, k))
+		if !c {
+			continue
+		}
+		removed, changed = removed+r, true
+		if !child.Valued && len(child.Children) == 0 {
+			delete(node.Children, k)
+		}
+	}
+	return removed, changed
+}
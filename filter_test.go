@@ -0,0 +1,91 @@
+package soytrie_test
+
+import (
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func TestFilter(t *testing.T) {
+	build := func() *soytrie.Node[int, int] {
+		root := soytrie.New[int, int]()
+		_ = root.Insert(1, 1)
+		_ = root.Insert(12, 1, 2)
+		_ = root.Insert(123, 1, 2, 3)
+		_ = root.Insert(14, 1, 4)
+		_ = root.Insert(567, 5, 6, 7)
+		_ = root.Insert(89, 8, 9)
+		_, _ = root.GetOrInsertDirect(0, soytrie.New[int, int]()) // pre-existing empty node
+		return root
+	}
+	even := func(_ []int, v int) bool { return v%2 == 0 }
+
+	countValues := func(n *soytrie.Node[int, int]) int {
+		count := 0
+		for range n.Values() {
+			count++
+		}
+		return count
+	}
+
+	t.Run("Filter", func(t *testing.T) {
+		root := build()
+		filtered, removed := root.Filter(even)
+		if removed != 4 {
+			t.Fatalf("unexpected removed count %d", removed)
+		}
+		if countValues(filtered) != 2 || countValues(root) != 6 {
+			t.Fatal("unexpected values after Filter")
+		}
+		if !filtered.Search(soytrie.ModeExact, 1, 2) || !filtered.Search(soytrie.ModeExact, 1, 4) {
+			t.Fatal("missing kept values")
+		}
+		if filtered.Search(soytrie.ModePrefix, 5) || filtered.Search(soytrie.ModePrefix, 8) {
+			t.Fatal("unexpected empty nodes left after Filter")
+		}
+	})
+
+	t.Run("DeleteIf", func(t *testing.T) {
+		root := build()
+		removed := root.DeleteIf(func(path []int, v int) bool {
+			return len(path) == 3
+		})
+		if removed != 2 {
+			t.Fatalf("unexpected removed count %d", removed)
+		}
+		if root.Search(soytrie.ModePrefix, 5) {
+			t.Fatal("unexpected empty nodes left after DeleteIf")
+		}
+		if root.Search(soytrie.ModePrefix, 1, 2, 3) || !root.Search(soytrie.ModeExact, 1, 2) {
+			t.Fatal("unexpected DeleteIf result")
+		}
+		if !root.Search(soytrie.ModePrefix, 0) {
+			t.Fatal("unexpected pruning of untouched empty node")
+		}
+	})
+
+	t.Run("Retain", func(t *testing.T) {
+		root := build()
+		removed := root.Retain(even)
+		if removed != 4 {
+			t.Fatalf("unexpected removed count %d", removed)
+		}
+		if countValues(root) != 2 {
+			t.Fatal("unexpected values after Retain")
+		}
+		node1, ok := root.Get(1)
+		if !ok || node1.Valued {
+			t.Fatal("unexpected node 1")
+		}
+		if l := len(node1.Children); l != 2 {
+			t.Fatalf("unexpected number of children %d", l)
+		}
+		if l := len(root.Children); l != 2 { // 1 and 0
+			t.Fatalf("unexpected number of root children %d", l)
+		}
+
+		if removed := root.Retain(even); removed != 0 {
+			t.Fatalf("unexpected removed count %d", removed)
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package soytrie
+
+// Fold reduces the trie under n in post-order. For every node,
+// leaf computes the node's own aggregate from its value, and combine
+// merges it with the aggregates of the node's children.
+// The children map is nil for nodes without children.
+func Fold[K comparable, V any, A any](
+	n *Node[K, V],
+	leaf func(path []K, v V, valued bool) A,
+	combine func(self A, children map[K]A) A,
+) A {
+	return fold(n, leaf, combine, nil, nil)
+}
+
+// FoldTree is like Fold, but also returns a trie with the same shape
+// as n, holding every node's aggregate as its value.
+func FoldTree[K comparable, V any, A any](
+	n *Node[K, V],
+	leaf func(path []K, v V, valued bool) A,
+	combine func(self A, children map[K]A) A,
+) *Node[K, A] {
+	result := New[K, A]()
+	fold(n, leaf, combine, nil, result)
+	return result
+}
+
+// fold implements Fold, writing aggregates to result if it is not nil
+func fold[K comparable, V any, A any](
+	node *Node[K, V],
+	leaf func([]K, V, bool) A,
+	combine func(A, map[K]A) A,
+	path []K,
+	result *Node[K, A],
+) A {
+	var children map[K]A
+	if len(node.Children) != 0 {
+		children = make(map[K]A, len(node.Children))
+	}
+	for k, child := range node.children() {
+		var childResult *Node[K, A]
+		if result != nil {
+			childResult, _ = result.GetOrInsertDirect(k, New[K, A]())
+		}
+		children[k] = fold(child, leaf, combine, append(path, k), childResult)
+	}
+
+	aggregate := combine(leaf(path, node.Value, node.Valued), children)
+	if result != nil {
+		result.Valued, result.Value = true, aggregate
+	}
+	return aggregate
+}
@@ -0,0 +1,92 @@
+package soytrie_test
+
+import (
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func TestFold(t *testing.T) {
+	// File sizes in a directory tree
+	root := soytrie.New[string, int]()
+	_ = root.Insert(100, "/src", "/main.go")
+	_ = root.Insert(20, "/src", "/testdata", "/a.txt")
+	_ = root.Insert(30, "/src", "/testdata", "/b.txt")
+	_ = root.Insert(1000, "/release", "/amd64", "/bin", "/foo")
+	_ = root.Insert(900, "/release", "/aarch64", "/bin", "/foo")
+
+	size := func(_ []string, v int, valued bool) int {
+		if !valued {
+			return 0
+		}
+		return v
+	}
+	sum := func(self int, children map[string]int) int {
+		for _, c := range children {
+			self += c
+		}
+		return self
+	}
+
+	if total := soytrie.Fold(root, size, sum); total != 2050 {
+		t.Fatalf("unexpected total size %d", total)
+	}
+
+	t.Run("count valued descendants", func(t *testing.T) {
+		count := soytrie.Fold(root, func(_ []string, _ int, valued bool) int {
+			if valued {
+				return 1
+			}
+			return 0
+		}, sum)
+		if count != 5 {
+			t.Fatalf("unexpected count %d", count)
+		}
+	})
+
+	t.Run("leaves see paths and nil children", func(t *testing.T) {
+		maxDepth := soytrie.Fold(root, func(path []string, _ int, _ bool) int {
+			return len(path)
+		}, func(self int, children map[string]int) int {
+			if children == nil && self == 0 {
+				t.Fatal("unexpected leaf at root")
+			}
+			for _, c := range children {
+				self = max(self, c)
+			}
+			return self
+		})
+		if maxDepth != 4 {
+			t.Fatalf("unexpected max depth %d", maxDepth)
+		}
+	})
+
+	t.Run("FoldTree", func(t *testing.T) {
+		sizes := soytrie.FoldTree(root, size, sum)
+		expected := map[int][]string{
+			2050: {},
+			150:  {"/src"},
+			50:   {"/src", "/testdata"},
+			1900: {"/release"},
+			1000: {"/release", "/amd64", "/bin"},
+			900:  {"/release", "/aarch64"},
+		}
+		for v, path := range expected {
+			node, ok := sizes.Get(path...)
+			if !ok || !node.Valued || node.Value != v {
+				t.Fatalf("unexpected aggregate %+v for path %v, expecting %d", node, path, v)
+			}
+		}
+
+		count := 0
+		for _, node := range sizes.All() {
+			if !node.Valued {
+				t.Fatal("unexpected non-valued node in FoldTree result")
+			}
+			count++
+		}
+		if count != 13 {
+			t.Fatalf("unexpected node count %d", count)
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package soytrie
+
+import "slices"
+
+// FuzzyEntry is an entry found by fuzzy search,
+// with its edit distance from the query
+type FuzzyEntry[K comparable, V any] struct {
+	Entry[K, V]
+	Distance int
+}
+
+// FuzzySearch returns all valued paths within maxDist
+// Levenshtein distance (insertions, deletions and substitutions) of path.
+//
+// The trie is walked with one dynamic programming row per depth,
+// and subtrees that cannot come within maxDist are skipped.
+func (n *Node[K, V]) FuzzySearch(maxDist int, path ...K) []FuzzyEntry[K, V] {
+	return n.fuzzySearch(maxDist, false, path)
+}
+
+// FuzzySearchTranspose is like FuzzySearch, but also counts
+// transpositions of adjacent keys as a single edit
+// (optimal string alignment distance).
+func (n *Node[K, V]) FuzzySearchTranspose(maxDist int, path ...K) []FuzzyEntry[K, V] {
+	return n.fuzzySearch(maxDist, true, path)
+}
+
+func (n *Node[K, V]) fuzzySearch(maxDist int, transpose bool, path []K) []FuzzyEntry[K, V] {
+	if maxDist < 0 {
+		return nil
+	}
+	f := fuzzer[K, V]{
+		query:     path,
+		maxDist:   maxDist,
+		transpose: transpose,
+		entries:   []FuzzyEntry[K, V]{},
+	}
+
+	row := make([]int, len(path)+1)
+	for j := range row {
+		row[j] = j
+	}
+	f.report(n, nil, row)
+	for k, child := range n.children() {
+		f.walk(child, k, []K{k}, nil, row)
+	}
+	return f.entries
+}
+
+type fuzzer[K comparable, V any] struct {
+	query     []K
+	maxDist   int
+	transpose bool
+	entries   []FuzzyEntry[K, V]
+}
+
+// walk computes the row for node (reached via key k)
+// from its parent's row and grandparent's row
+func (f *fuzzer[K, V]) walk(node *Node[K, V], k K, path []K, grandRow, parentRow []int) {
+	depth := len(path)
+	row := make([]int, len(f.query)+1)
+	row[0] = depth
+	lowest := row[0]
+	for j := 1; j < len(row); j++ {
+		cost := 1
+		if f.query[j-1] == k {
+			cost = 0
+		}
+		row[j] = min(
+			parentRow[j]+1,      // deletion
+			row[j-1]+1,          // insertion
+			parentRow[j-1]+cost, // substitution
+		)
+		if f.transpose && grandRow != nil && j > 1 &&
+			f.query[j-1] == path[depth-2] && f.query[j-2] == k {
+			row[j] = min(row[j], grandRow[j-2]+1)
+		}
+		lowest = min(lowest, row[j])
+	}
+
+	if lowest > f.maxDist {
+		return
+	}
+	f.report(node, path, row)
+	for next, child := range node.children() {
+		f.walk(child, next, append(path, next), parentRow, row)
+	}
+}
+
+func (f *fuzzer[K, V]) report(node *Node[K, V], path []K, row []int) {
+	dist := row[len(row)-1]
+	if !node.Valued || dist > f.maxDist {
+		return
+	}
+	f.entries = append(f.entries, FuzzyEntry[K, V]{
+		Entry: Entry[K, V]{
+			Path:   slices.Clone(path),
+			Value:  node.Value,
+			Valued: true,
+		},
+		Distance: dist,
+	})
+}
@@ -0,0 +1,99 @@
+package soytrie_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func TestFuzzySearch(t *testing.T) {
+	words := []string{
+		"car", "cart", "care", "cat", "cats", "act", "dog", "dot", "do", "ca", "scar", "",
+	}
+	root := soytrie.New[rune, string]()
+	for _, w := range words {
+		if w == "" {
+			root.Valued, root.Value = true, w
+			continue
+		}
+		r := []rune(w)
+		_ = root.Insert(w, r[0], r[1:]...)
+	}
+
+	for _, transpose := range []bool{false, true} {
+		for _, query := range []string{"car", "cta", "dgo", "xyz", "", "crate"} {
+			for maxDist := range 3 {
+				search := root.FuzzySearch
+				if transpose {
+					search = root.FuzzySearchTranspose
+				}
+
+				actual := map[string]int{}
+				for _, e := range search(maxDist, []rune(query)...) {
+					if e.Value != string(e.Path) {
+						t.Fatalf("unexpected entry %+v", e)
+					}
+					actual[e.Value] = e.Distance
+				}
+
+				expected := map[string]int{}
+				for _, w := range words {
+					if d := editDistance([]rune(w), []rune(query), transpose); d <= maxDist {
+						expected[w] = d
+					}
+				}
+
+				if len(actual) != len(expected) {
+					t.Fatalf("unexpected matches %v for query=%s,maxDist=%d,transpose=%v, expecting %v", actual, query, maxDist, transpose, expected)
+				}
+				for w, d := range expected {
+					if actual[w] != d {
+						t.Fatalf("unexpected distance %d for %s (query=%s), expecting %d", actual[w], w, query, d)
+					}
+				}
+			}
+		}
+	}
+
+	t.Run("transposition", func(t *testing.T) {
+		plain := root.FuzzySearch(1, []rune("cta")...)
+		transposed := root.FuzzySearchTranspose(1, []rune("cta")...)
+		has := func(entries []soytrie.FuzzyEntry[rune, string], w string) bool {
+			return slices.ContainsFunc(entries, func(e soytrie.FuzzyEntry[rune, string]) bool {
+				return e.Value == w
+			})
+		}
+		if has(plain, "cat") {
+			t.Fatal("unexpected cat without transposition")
+		}
+		if !has(transposed, "cat") {
+			t.Fatal("missing cat with transposition")
+		}
+	})
+}
+
+// editDistance is a brute-force reference implementation
+func editDistance(a, b []rune, transpose bool) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if transpose && i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
@@ -0,0 +1,333 @@
+package soytrie
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+type globKind uint8
+
+const (
+	globKey globKind = iota
+	globAny
+	globAnyDepth
+	globFunc
+)
+
+// GlobSegment is one element of a glob pattern
+type GlobSegment[K comparable] struct {
+	kind globKind
+	key  K
+	fn   func(K) bool
+}
+
+// GlobKey matches exactly k
+func GlobKey[K comparable](k K) GlobSegment[K] {
+	return GlobSegment[K]{kind: globKey, key: k}
+}
+
+// GlobAny matches any single key
+func GlobAny[K comparable]() GlobSegment[K] {
+	return GlobSegment[K]{kind: globAny}
+}
+
+// GlobAnyDepth matches any number of keys, including none
+func GlobAnyDepth[K comparable]() GlobSegment[K] {
+	return GlobSegment[K]{kind: globAnyDepth}
+}
+
+// GlobFunc matches any single key for which fn returns true
+func GlobFunc[K comparable](fn func(K) bool) GlobSegment[K] {
+	return GlobSegment[K]{kind: globFunc, fn: fn}
+}
+
+// Glob returns all valued nodes whose paths match pattern.
+// Literal segments are looked up directly, so only the parts
+// of the trie that can match are visited.
+func (n *Node[K, V]) Glob(pattern ...GlobSegment[K]) []Entry[K, V] {
+	g := globber[K, V]{pattern: pattern, entries: []Entry[K, V]{}}
+	if slices.ContainsFunc(pattern, func(s GlobSegment[K]) bool { return s.kind == globAnyDepth }) {
+		// With "**", the same node can be reached by different
+		// splits of the path, so we remember visited states
+		g.seen = make(map[globState[K, V]]struct{})
+	}
+	g.glob(n, 0, nil)
+	return g.entries
+}
+
+type globState[K comparable, V any] struct {
+	node *Node[K, V]
+	i    int
+}
+
+type globber[K comparable, V any] struct {
+	pattern []GlobSegment[K]
+	seen    map[globState[K, V]]struct{}
+	entries []Entry[K, V]
+}
+
+func (g *globber[K, V]) glob(node *Node[K, V], i int, path []K) {
+	if g.seen != nil {
+		state := globState[K, V]{node: node, i: i}
+		if _, ok := g.seen[state]; ok {
+			return
+		}
+		g.seen[state] = struct{}{}
+	}
+	if i == len(g.pattern) {
+		appendEntry(node, path, &g.entries)
+		return
+	}
+
+	seg := &g.pattern[i]
+	switch seg.kind {
+	case globKey:
+		if next, ok := node.GetDirect(seg.key); ok {
+			g.glob(next, i+1, append(path, seg.key))
+		}
+
+	case globAny, globFunc:
+		for k, child := range node.children() {
+			if seg.kind == globFunc && !seg.fn(k) {
+				continue
+			}
+			g.glob(child, i+1, append(path, k))
+		}
+
+	case globAnyDepth:
+		g.glob(node, i+1, path)
+		for k, child := range node.children() {
+			g.glob(child, i, append(path, k))
+		}
+	}
+}
+
+// ParseGlob parses a string glob pattern whose segments are separated
+// by sep. A segment "*" matches any single key, and "**" matches any
+// number of keys. Other segments may use "*", "?" and "[...]" as in
+// path.Match, and "{a,b}" alternation. Unlike path.Match, "*" and "?"
+// also match "/", since keys are not file paths.
+func ParseGlob(pattern, sep string) ([]GlobSegment[string], error) {
+	segments := []GlobSegment[string]{}
+	for _, s := range strings.Split(pattern, sep) {
+		switch {
+		case s == "*":
+			segments = append(segments, GlobAny[string]())
+
+		case s == "**":
+			segments = append(segments, GlobAnyDepth[string]())
+
+		case !strings.ContainsAny(s, `*?[{\`):
+			segments = append(segments, GlobKey(s))
+
+		default:
+			fn, err := compileGlob(s)
+			if err != nil {
+				return nil, fmt.Errorf("bad glob segment %q: %w", s, err)
+			}
+			segments = append(segments, GlobFunc(fn))
+		}
+	}
+	return segments, nil
+}
+
+// compileGlob compiles a single segment pattern into a predicate
+func compileGlob(s string) (func(string) bool, error) {
+	alternatives, err := expandBraces(s)
+	if err != nil {
+		return nil, err
+	}
+	for _, alt := range alternatives {
+		err := checkSegment(alt)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return func(k string) bool {
+		for _, alt := range alternatives {
+			if matchSegment(alt, k) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// checkSegment returns path.ErrBadPattern if pattern is malformed
+func checkSegment(pattern string) error {
+	for i := 0; i < len(pattern); {
+		switch pattern[i] {
+		case '\\':
+			if i+1 == len(pattern) {
+				return path.ErrBadPattern
+			}
+			_, w := utf8.DecodeRuneInString(pattern[i+1:])
+			i += 1 + w
+		case '[':
+			n, _ := matchClass(pattern[i+1:], utf8.RuneError)
+			if n == 0 {
+				return path.ErrBadPattern
+			}
+			i += 1 + n
+		default:
+			i++
+		}
+	}
+	return nil
+}
+
+// matchSegment reports whether key matches pattern. The syntax is that
+// of path.Match, but no character is a separator. pattern must have
+// been checked with checkSegment.
+func matchSegment(pattern, key string) bool {
+	px, kx := 0, 0
+	starPx, starKx := -1, 0
+	for px < len(pattern) || kx < len(key) {
+		if px < len(pattern) {
+			switch pattern[px] {
+			case '*':
+				starPx, starKx = px, kx
+				px++
+				continue
+
+			case '?':
+				if kx < len(key) {
+					_, w := utf8.DecodeRuneInString(key[kx:])
+					px, kx = px+1, kx+w
+					continue
+				}
+
+			case '[':
+				if kx < len(key) {
+					r, w := utf8.DecodeRuneInString(key[kx:])
+					if n, ok := matchClass(pattern[px+1:], r); ok {
+						px, kx = px+1+n, kx+w
+						continue
+					}
+				}
+
+			default:
+				lit := px
+				if pattern[px] == '\\' {
+					lit++
+				}
+				_, w := utf8.DecodeRuneInString(pattern[lit:])
+				if strings.HasPrefix(key[kx:], pattern[lit:lit+w]) {
+					px, kx = lit+w, kx+w
+					continue
+				}
+			}
+		}
+
+		// Mismatch: let the last "*" consume one more character
+		if starPx == -1 || starKx == len(key) {
+			return false
+		}
+		_, w := utf8.DecodeRuneInString(key[starKx:])
+		starKx += w
+		px, kx = starPx+1, starKx
+	}
+	return true
+}
+
+// matchClass matches r against the character class at the start
+// of pattern, just after the "[". It returns the length of the class
+// including the "]", or 0 if the class is malformed.
+func matchClass(pattern string, r rune) (int, bool) {
+	i, negate, matched := 0, false, false
+	if i < len(pattern) && pattern[i] == '^' {
+		negate = true
+		i++
+	}
+	for ranges := 0; ; ranges++ {
+		if i == len(pattern) {
+			return 0, false
+		}
+		if pattern[i] == ']' && ranges > 0 {
+			return i + 1, matched != negate
+		}
+		lo, w := classChar(pattern[i:])
+		if w == 0 {
+			return 0, false
+		}
+		i += w
+		hi := lo
+		if i < len(pattern) && pattern[i] == '-' {
+			hi, w = classChar(pattern[i+1:])
+			if w == 0 || hi < lo {
+				return 0, false
+			}
+			i += 1 + w
+		}
+		if lo <= r && r <= hi {
+			matched = true
+		}
+	}
+}
+
+// classChar decodes a possibly escaped character in a class,
+// returning a width of 0 if there is none
+func classChar(s string) (rune, int) {
+	if s == "" || s[0] == '-' || s[0] == ']' {
+		return 0, 0
+	}
+	if s[0] == '\\' {
+		if len(s) == 1 {
+			return 0, 0
+		}
+		r, w := utf8.DecodeRuneInString(s[1:])
+		return r, 1 + w
+	}
+	return utf8.DecodeRuneInString(s)
+}
+
+var errBadBraces = errors.New("unbalanced braces")
+
+// expandBraces expands "{a,b}" alternations, which may be nested,
+// into all alternative patterns
+func expandBraces(s string) ([]string, error) {
+	open := strings.IndexByte(s, '{')
+	if open == -1 {
+		if strings.IndexByte(s, '}') != -1 {
+			return nil, errBadBraces
+		}
+		return []string{s}, nil
+	}
+
+	// Find the matching close brace and top-level commas
+	depth, commas, end := 0, []int{}, -1
+	for i := open; i < len(s) && end == -1; i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				end = i
+			}
+		case ',':
+			if depth == 1 {
+				commas = append(commas, i)
+			}
+		}
+	}
+	if end == -1 {
+		return nil, errBadBraces
+	}
+
+	prefix, suffix := s[:open], s[end+1:]
+	bounds := append(append([]int{open}, commas...), end)
+	results := []string{}
+	for j := 0; j < len(bounds)-1; j++ {
+		alts, err := expandBraces(prefix + s[bounds[j]+1:bounds[j+1]] + suffix)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, alts...)
+	}
+	return results, nil
+}
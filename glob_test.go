@@ -0,0 +1,159 @@
+package soytrie_test
+
+import (
+	"math/rand/v2"
+	"path"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func TestGlob(t *testing.T) {
+	root := soytrie.New[string, string]()
+	metrics := []string{
+		"servers.web01.cpu.user",
+		"servers.web01.cpu.system",
+		"servers.web02.cpu.user",
+		"servers.web02.mem.free",
+		"servers.db01.cpu.user",
+		"servers.db01.disk.sda.used",
+		"servers.db01.disk.sdb.used",
+		"servers",
+		"apps.api.latency.p99",
+	}
+	for _, m := range metrics {
+		p := strings.Split(m, ".")
+		_ = root.Insert(m, p[0], p[1:]...)
+	}
+
+	type testCase struct {
+		pattern  string
+		expected []string
+	}
+
+	tests := []testCase{
+		{pattern: "servers.*.cpu.user", expected: []string{"servers.db01.cpu.user", "servers.web01.cpu.user", "servers.web02.cpu.user"}},
+		{pattern: "servers.web0?.cpu.*", expected: []string{"servers.web01.cpu.system", "servers.web01.cpu.user", "servers.web02.cpu.user"}},
+		{pattern: "servers.web0[2-9].*.*", expected: []string{"servers.web02.cpu.user", "servers.web02.mem.free"}},
+		{pattern: "servers.{web01,db01}.cpu.user", expected: []string{"servers.db01.cpu.user", "servers.web01.cpu.user"}},
+		{pattern: "servers.{web,db}01.cpu.user", expected: []string{"servers.db01.cpu.user", "servers.web01.cpu.user"}},
+		{pattern: "servers.db01.disk.sd{a,b}.used", expected: []string{"servers.db01.disk.sda.used", "servers.db01.disk.sdb.used"}},
+		{pattern: "servers.**.used", expected: []string{"servers.db01.disk.sda.used", "servers.db01.disk.sdb.used"}},
+		{pattern: "**.user", expected: []string{"servers.db01.cpu.user", "servers.web01.cpu.user", "servers.web02.cpu.user"}},
+		{pattern: "servers.**", expected: []string{
+			"servers", "servers.db01.cpu.user", "servers.db01.disk.sda.used", "servers.db01.disk.sdb.used",
+			"servers.web01.cpu.system", "servers.web01.cpu.user", "servers.web02.cpu.user", "servers.web02.mem.free",
+		}},
+		{pattern: "**.**.p99", expected: []string{"apps.api.latency.p99"}},
+		{pattern: "**.cpu.**", expected: []string{"servers.db01.cpu.user", "servers.web01.cpu.system", "servers.web01.cpu.user", "servers.web02.cpu.user"}},
+		{pattern: "servers.*", expected: []string{}},
+		{pattern: "nosuch.**", expected: []string{}},
+	}
+
+	for i := range tests {
+		tc := &tests[i]
+		pattern, err := soytrie.ParseGlob(tc.pattern, ".")
+		if err != nil {
+			t.Fatalf("[case %d] unexpected error: %v", i, err)
+		}
+		actual := []string{}
+		for _, e := range root.Glob(pattern...) {
+			if e.Value != strings.Join(e.Path, ".") {
+				t.Fatalf("[case %d] unexpected entry %+v", i, e)
+			}
+			actual = append(actual, e.Value)
+		}
+		slices.Sort(actual)
+		if !slices.Equal(tc.expected, actual) {
+			t.Fatalf("[case %d] unexpected matches %v for %s, expecting %v", i, actual, tc.pattern, tc.expected)
+		}
+	}
+
+	t.Run("generic segments", func(t *testing.T) {
+		root := soytrie.New[int, string]()
+		_ = root.Insert("1,2,3", 1, 2, 3)
+		_ = root.Insert("1,4,3", 1, 4, 3)
+		_ = root.Insert("1,5,6,3", 1, 5, 6, 3)
+
+		even := soytrie.GlobFunc(func(k int) bool { return k%2 == 0 })
+		entries := root.Glob(soytrie.GlobKey(1), even, soytrie.GlobKey(3))
+		if l := len(entries); l != 2 {
+			t.Fatalf("unexpected length %d", l)
+		}
+		entries = root.Glob(soytrie.GlobKey(1), soytrie.GlobAnyDepth[int](), soytrie.GlobKey(3))
+		if l := len(entries); l != 3 {
+			t.Fatalf("unexpected length %d", l)
+		}
+		entries = root.Glob(soytrie.GlobAny[int](), soytrie.GlobAny[int](), soytrie.GlobAny[int]())
+		if l := len(entries); l != 2 {
+			t.Fatalf("unexpected length %d", l)
+		}
+	})
+
+	t.Run("keys with slashes", func(t *testing.T) {
+		root := soytrie.New[string, string]()
+		_ = root.Insert("v1/users", "api", "v1/users")
+		_ = root.Insert("v2", "api", "v2")
+
+		tests := map[string][]string{
+			"api.v1*":         {"v1/users"},
+			"api.v1?users":    {"v1/users"},
+			"api.*/*":         {"v1/users"},
+			"api.v1[/]users":  {"v1/users"},
+			`api.v1\/users`:   {"v1/users"},
+			"api.v[12]*":      {"v1/users", "v2"},
+			"api.v1[^/]users": {},
+		}
+		for pattern, expected := range tests {
+			segments, err := soytrie.ParseGlob(pattern, ".")
+			if err != nil {
+				t.Fatalf("unexpected error for %s: %v", pattern, err)
+			}
+			actual := []string{}
+			for _, e := range root.Glob(segments...) {
+				actual = append(actual, e.Value)
+			}
+			slices.Sort(actual)
+			if !slices.Equal(actual, expected) {
+				t.Fatalf("unexpected matches %v for %s, expecting %v", actual, pattern, expected)
+			}
+		}
+	})
+
+	t.Run("matches path.Match without slashes", func(t *testing.T) {
+		rng := rand.New(rand.NewPCG(7, 8))
+		atoms := []string{"a", "b", "é", "*", "?", "[ab]", "[^a]", "[a-c]", `\*`, "**"}
+		keys := []string{"", "a", "b", "ab", "ba", "aab", "é", "aé", "*", "abc", "cab"}
+		for range 2000 {
+			pattern := ""
+			for range 1 + rng.IntN(4) {
+				pattern += atoms[rng.IntN(len(atoms))]
+			}
+			if pattern == "*" || pattern == "**" {
+				continue // parsed as GlobAny and GlobAnyDepth
+			}
+			segments, err := soytrie.ParseGlob(pattern, ".")
+			if err != nil {
+				t.Fatalf("unexpected error for %s: %v", pattern, err)
+			}
+			for _, key := range keys {
+				root := soytrie.New[string, string]()
+				_ = root.Insert(key, key)
+				expected, _ := path.Match(pattern, key)
+				if actual := len(root.Glob(segments...)) == 1; actual != expected {
+					t.Fatalf("unexpected match %v for %q with %q", actual, key, pattern)
+				}
+			}
+		}
+	})
+
+	t.Run("bad patterns", func(t *testing.T) {
+		for _, p := range []string{"a.{b,c", "a.{b}}", "a.[b", "a.[]", "a.[c-a]", "a.[a-]", `a.b\`} {
+			if _, err := soytrie.ParseGlob(p, "."); err == nil {
+				t.Fatalf("unexpected nil error for %s", p)
+			}
+		}
+	})
+}
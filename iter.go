@@ -0,0 +1,68 @@
+package soytrie
+
+import "iter"
+
+// All returns an iterator over every node under n (including n itself),
+// paired with the node's path relative to n.
+//
+// The yielded path is only valid until the next iteration;
+// callers that want to keep it must copy it.
+func (n *Node[K, V]) All() iter.Seq2[[]K, *Node[K, V]] {
+	return func(yield func([]K, *Node[K, V]) bool) {
+		walkSeq(nil, n, nil, yield)
+	}
+}
+
+// Values returns an iterator over all valued nodes under n
+// (including n itself), paired with the node's path relative to n.
+//
+// The yielded path is only valid until the next iteration.
+func (n *Node[K, V]) Values() iter.Seq2[[]K, *Node[K, V]] {
+	return func(yield func([]K, *Node[K, V]) bool) {
+		walkSeq(isValued[K, V], n, nil, yield)
+	}
+}
+
+// WithPrefix returns an iterator over all valued nodes
+// whose paths start with path. The yielded paths include the prefix.
+// If path does not exist, the iterator yields nothing.
+//
+// The yielded path is only valid until the next iteration.
+func (n *Node[K, V]) WithPrefix(path ...K) iter.Seq2[[]K, *Node[K, V]] {
+	return func(yield func([]K, *Node[K, V]) bool) {
+		target, ok := n.Get(path...)
+		if !ok {
+			return
+		}
+		buf := make([]K, len(path), len(path)+8)
+		copy(buf, path)
+		walkSeq(isValued[K, V], target, buf, yield)
+	}
+}
+
+func isValued[K comparable, V any](n *Node[K, V]) bool {
+	return n.Valued
+}
+
+// walkSeq walks node in pre-order, yielding nodes that pass testFn.
+// It returns false if yield asked to stop.
+func walkSeq[K comparable, V any](
+	testFn func(*Node[K, V]) bool,
+	node *Node[K, V],
+	path []K,
+	yield func([]K, *Node[K, V]) bool,
+) bool {
+	if testFn == nil || testFn(node) {
+		// Clip capacity so that callers appending to path
+		// cannot clobber our buffer
+		if !yield(path[:len(path):len(path)], node) {
+			return false
+		}
+	}
+	for k, child := range node.children() {
+		if !walkSeq(testFn, child, append(path, k), yield) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package soytrie_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func TestIterators(t *testing.T) {
+	root := soytrie.New[int, string]()
+	_ = root.Insert("1", 1)
+	_ = root.Insert("1,2", 1, 2)
+	_ = root.Insert("1,2,3", 1, 2, 3)
+	_ = root.Insert("2", 2)
+	_ = root.Insert("2,3", 2, 3)
+	_ = root.Insert("10,20,30,40,50", 10, 20, 30, 40, 50)
+
+	t.Run("All", func(t *testing.T) {
+		count := 0
+		for path, node := range root.All() {
+			count++
+			if actual, ok := root.Get(path...); !ok || actual != node {
+				t.Fatalf("unexpected node for path %v", path)
+			}
+		}
+		if count != 11 { // 10 nodes plus root
+			t.Fatalf("unexpected count %d", count)
+		}
+	})
+
+	t.Run("Values", func(t *testing.T) {
+		values := []string{}
+		for path, node := range root.Values() {
+			if !node.Valued {
+				t.Fatalf("unexpected non-valued node at path %v", path)
+			}
+			if actual, ok := root.Get(path...); !ok || actual != node {
+				t.Fatalf("unexpected node for path %v", path)
+			}
+			values = append(values, node.Value)
+		}
+		slices.Sort(values)
+		expected := []string{"1", "1,2", "1,2,3", "10,20,30,40,50", "2", "2,3"}
+		if !slices.Equal(expected, values) {
+			t.Fatalf("unexpected values %v, expecting %v", values, expected)
+		}
+	})
+
+	t.Run("WithPrefix", func(t *testing.T) {
+		paths := [][]int{}
+		for path, node := range root.WithPrefix(1) {
+			if path[0] != 1 {
+				t.Fatalf("unexpected path %v without prefix", path)
+			}
+			if actual, ok := root.Get(path...); !ok || actual != node {
+				t.Fatalf("unexpected node for path %v", path)
+			}
+			paths = append(paths, slices.Clone(path))
+		}
+		if l := len(paths); l != 3 {
+			t.Fatalf("unexpected number of paths %d", l)
+		}
+
+		for path := range root.WithPrefix(3) {
+			t.Fatalf("unexpected path %v for missing prefix", path)
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		count := 0
+		for range root.All() {
+			count++
+			if count == 2 {
+				break
+			}
+		}
+		if count != 2 {
+			t.Fatalf("unexpected count %d", count)
+		}
+	})
+
+	t.Run("appending to yielded path", func(t *testing.T) {
+		for path := range root.WithPrefix(10, 20) {
+			_ = append(path, -1)
+		}
+		count := 0
+		for path := range root.WithPrefix(10, 20) {
+			if !slices.Equal(path, []int{10, 20, 30, 40, 50}) {
+				t.Fatalf("unexpected path %v", path)
+			}
+			count++
+		}
+		if count != 1 {
+			t.Fatalf("unexpected count %d", count)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package soytrie
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// jsonNode is the nested JSON layout of a node.
+// The "value" key is only present on valued nodes,
+// so valued nodes holding zero values survive a round trip.
+type jsonNode[K comparable, V any] struct {
+	Value    *V                `json:"value,omitempty"`
+	Children map[K]*Node[K, V] `json:"children,omitempty"`
+}
+
+// jsonNodeDecode mirrors jsonNode, but keeps the value raw
+// so that we can tell a JSON null value from a missing one.
+type jsonNodeDecode[K comparable, V any] struct {
+	Value    json.RawMessage   `json:"value"`
+	Children map[K]*Node[K, V] `json:"children"`
+}
+
+// jsonEntry is an element of the flat JSON layout
+type jsonEntry[K comparable, V any] struct {
+	Path  []K `json:"path"`
+	Value V   `json:"value"`
+}
+
+// MarshalJSON encodes n as nested objects, with children keyed
+// by their keys' JSON map key form (strings, integers,
+// or encoding.TextMarshaler).
+func (n *Node[K, V]) MarshalJSON() ([]byte, error) {
+	j := jsonNode[K, V]{Children: n.Children}
+	if n.Valued {
+		v := n.Value
+		j.Value = &v
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes the nested layout written by MarshalJSON,
+// replacing n's value and children.
+func (n *Node[K, V]) UnmarshalJSON(data []byte) error {
+	var j jsonNodeDecode[K, V]
+	err := json.Unmarshal(data, &j)
+	if err != nil {
+		return err
+	}
+
+	for k, child := range j.Children {
+		if child == nil {
+			return fmt.Errorf("null child at key %v", k)
+		}
+	}
+
+	var value V
+	valued := len(j.Value) != 0
+	if valued {
+		err = json.Unmarshal(j.Value, &value)
+		if err != nil {
+			return err
+		}
+	}
+
+	n.Value, n.Valued, n.Children = value, valued, j.Children
+	if n.order != nil {
+		n.SetOrder(n.order)
+	}
+	return nil
+}
+
+// FlatJSON wraps a root node to marshal it as a flat JSON list
+// of {"path": [...], "value": ...} entries, one for each valued node.
+type FlatJSON[K comparable, V any] struct {
+	Root *Node[K, V]
+}
+
+func (f FlatJSON[K, V]) MarshalJSON() ([]byte, error) {
+	entries := []jsonEntry[K, V]{}
+	if f.Root != nil {
+		for path, node := range f.Root.Values() {
+			entries = append(entries, jsonEntry[K, V]{
+				Path:  append([]K{}, path...),
+				Value: node.Value,
+			})
+		}
+	}
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON inserts the decoded entries into f.Root,
+// allocating a new root if f.Root is nil.
+func (f *FlatJSON[K, V]) UnmarshalJSON(data []byte) error {
+	var entries []jsonEntry[K, V]
+	err := json.Unmarshal(data, &entries)
+	if err != nil {
+		return err
+	}
+	if f.Root == nil {
+		f.Root = New[K, V]()
+	}
+	for i := range entries {
+		e := &entries[i]
+		if len(e.Path) == 0 {
+			f.Root.Valued, f.Root.Value = true, e.Value
+			continue
+		}
+		f.Root.Insert(e.Value, e.Path[0], e.Path[1:]...)
+	}
+	return nil
+}
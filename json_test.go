@@ -0,0 +1,141 @@
+package soytrie_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func TestJSON(t *testing.T) {
+	root := soytrie.New[int, *int]()
+	zero, one := 0, 1
+	_ = root.Insert(&one, 1)
+	_ = root.Insert(&zero, 1, 2)
+	_ = root.Insert(nil, 1, 2, 3) // valued node with JSON null
+	_ = root.Insert(&one, 5, 6, 7)
+
+	assertEqual := func(t *testing.T, decoded *soytrie.Node[int, *int]) {
+		t.Helper()
+		for path, node := range root.All() {
+			other, ok := decoded.Get(path...)
+			if !ok {
+				t.Fatalf("missing path %v", path)
+			}
+			if other.Valued != node.Valued {
+				t.Fatalf("unexpected valued=%v for path %v", other.Valued, path)
+			}
+			if !node.Valued {
+				continue
+			}
+			if (node.Value == nil) != (other.Value == nil) {
+				t.Fatalf("unexpected value %v for path %v", other.Value, path)
+			}
+			if node.Value != nil && *node.Value != *other.Value {
+				t.Fatalf("unexpected value %d for path %v", *other.Value, path)
+			}
+		}
+	}
+
+	t.Run("nested", func(t *testing.T) {
+		data, err := json.Marshal(root)
+		if err != nil {
+			t.Fatal("unexpected error", err)
+		}
+		if !strings.Contains(string(data), `"children"`) {
+			t.Fatalf("unexpected nested layout %s", data)
+		}
+
+		decoded := soytrie.New[int, *int]()
+		err = json.Unmarshal(data, decoded)
+		if err != nil {
+			t.Fatal("unexpected error", err)
+		}
+		assertEqual(t, decoded)
+
+		if decoded.Valued {
+			t.Fatal("unexpected valued root")
+		}
+		node56, _ := decoded.Get(5, 6)
+		if node56.Valued {
+			t.Fatal("unexpected valued node 5,6")
+		}
+	})
+
+	t.Run("flat", func(t *testing.T) {
+		data, err := json.Marshal(soytrie.FlatJSON[int, *int]{Root: root})
+		if err != nil {
+			t.Fatal("unexpected error", err)
+		}
+
+		var entries []map[string]any
+		err = json.Unmarshal(data, &entries)
+		if err != nil {
+			t.Fatal("unexpected error", err)
+		}
+		if l := len(entries); l != 4 {
+			t.Fatalf("unexpected number of entries %d", l)
+		}
+
+		var flat soytrie.FlatJSON[int, *int]
+		err = json.Unmarshal(data, &flat)
+		if err != nil {
+			t.Fatal("unexpected error", err)
+		}
+		assertEqual(t, flat.Root)
+	})
+
+	t.Run("zero value with string keys", func(t *testing.T) {
+		root := soytrie.NewWithValue[string]("")
+		_ = root.Insert("", "/src")
+		_ = root.Insert("data", "/src", "/testdata")
+
+		data, err := json.Marshal(root)
+		if err != nil {
+			t.Fatal("unexpected error", err)
+		}
+		decoded := soytrie.New[string, string]()
+		err = json.Unmarshal(data, decoded)
+		if err != nil {
+			t.Fatal("unexpected error", err)
+		}
+		if !decoded.Valued {
+			t.Fatal("unexpected non-valued root")
+		}
+		if !decoded.Search(soytrie.ModeExact, "/src") {
+			t.Fatal("unexpected non-valued /src")
+		}
+		node, _ := decoded.Get("/src", "/testdata")
+		if node.Value != "data" {
+			t.Fatalf("unexpected value '%s'", node.Value)
+		}
+
+		data, err = json.Marshal(soytrie.FlatJSON[string, string]{Root: root})
+		if err != nil {
+			t.Fatal("unexpected error", err)
+		}
+		var flat soytrie.FlatJSON[string, string]
+		err = json.Unmarshal(data, &flat)
+		if err != nil {
+			t.Fatal("unexpected error", err)
+		}
+		if !flat.Root.Valued || !flat.Root.Search(soytrie.ModeExact, "/src") {
+			t.Fatal("unexpected loss of zero values")
+		}
+	})
+
+	t.Run("bad input", func(t *testing.T) {
+		decoded := soytrie.New[int, int]()
+		for _, input := range []string{
+			`{"children":{"x":{}}}`,
+			`{"children":{"1":null}}`,
+			`{"children":{"1":{"children":{"2":null}}}}`,
+		} {
+			err := json.Unmarshal([]byte(input), decoded)
+			if err == nil {
+				t.Fatalf("unexpected nil error for %s", input)
+			}
+		}
+	})
+}
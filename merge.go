@@ -0,0 +1,64 @@
+package soytrie
+
+// Merge merges src into dst, and returns the number of conflicts,
+// i.e. paths valued in both tries.
+//
+// On conflicts, resolve decides the merged value (src wins if resolve is nil).
+// The path passed to resolve is only valid until resolve returns.
+// Otherwise, whichever side is valued wins. Subtrees only found in src
+// are shared with dst, so later changes to them are visible in both tries.
+// If dst has an Order, those subtrees are copied as with MergeCopy
+// instead, so that giving them dst's Order leaves src unchanged.
+// Use MergeCopy to always avoid sharing.
+func Merge[K comparable, V any](
+	dst *Node[K, V],
+	src *Node[K, V],
+	resolve func(path []K, a, b V) V,
+) int {
+	return merge(dst, src, resolve, false, nil)
+}
+
+// MergeCopy is like Merge, but deep-copies subtrees
+// only found in src instead of sharing them
+func MergeCopy[K comparable, V any](
+	dst *Node[K, V],
+	src *Node[K, V],
+	resolve func(path []K, a, b V) V,
+) int {
+	return merge(dst, src, resolve, true, nil)
+}
+
+func merge[K comparable, V any](
+	dst *Node[K, V],
+	src *Node[K, V],
+	resolve func(path []K, a, b V) V,
+	deepCopy bool,
+	path []K,
+) int {
+	conflicts := 0
+	switch {
+	case dst.Valued && src.Valued:
+		conflicts++
+		if resolve == nil {
+			dst.Value = src.Value
+		} else {
+			dst.Value = resolve(path, dst.Value, src.Value)
+		}
+
+	case src.Valued:
+		dst.Valued, dst.Value = true, src.Value
+	}
+
+	for k, srcChild := range src.children() {
+		dstChild, ok := dst.GetDirect(k)
+		if ok {
+			conflicts += merge(dstChild, srcChild, resolve, deepCopy, append(path, k))
+			continue
+		}
+		if deepCopy || dst.order != nil {
+			srcChild = srcChild.Clone()
+		}
+		dst.GetOrInsertDirect(k, srcChild)
+	}
+	return conflicts
+}
@@ -0,0 +1,184 @@
+package soytrie_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func TestMerge(t *testing.T) {
+	build := func() (*soytrie.Node[int, int], *soytrie.Node[int, int]) {
+		dst := soytrie.New[int, int]()
+		_ = dst.Insert(1, 1)
+		_ = dst.Insert(12, 1, 2)
+		_ = dst.Insert(123, 1, 2, 3)
+		_ = dst.Insert(5, 5)
+
+		src := soytrie.New[int, int]()
+		_ = src.Insert(100, 1)     // conflict
+		_ = src.Insert(1200, 1, 2) // conflict
+		_ = src.Insert(13, 1, 3)   // new subtree
+		_ = src.Insert(134, 1, 3, 4)
+		_ = src.Insert(56, 5, 6) // new subtree under existing node
+		_ = src.Insert(7, 7)     // new subtree
+		return dst, src
+	}
+
+	t.Run("resolve", func(t *testing.T) {
+		dst, src := build()
+		conflictPaths := [][]int{}
+		conflicts := soytrie.Merge(dst, src, func(path []int, a, b int) int {
+			conflictPaths = append(conflictPaths, slices.Clone(path))
+			return a + b
+		})
+		if conflicts != 2 || len(conflictPaths) != 2 {
+			t.Fatalf("unexpected conflicts %d %v", conflicts, conflictPaths)
+		}
+
+		expected := map[int][]int{
+			101:  {1},
+			1212: {1, 2},
+			123:  {1, 2, 3},
+			13:   {1, 3},
+			134:  {1, 3, 4},
+			5:    {5},
+			56:   {5, 6},
+			7:    {7},
+		}
+		count := 0
+		for path, node := range dst.Values() {
+			count++
+			if expectedPath, ok := expected[node.Value]; !ok || !slices.Equal(expectedPath, path) {
+				t.Fatalf("unexpected value %d at path %v", node.Value, path)
+			}
+		}
+		if count != len(expected) {
+			t.Fatalf("unexpected number of values %d", count)
+		}
+	})
+
+	t.Run("nil resolve and sharing", func(t *testing.T) {
+		dst, src := build()
+		_ = soytrie.Merge(dst, src, nil)
+		node1, _ := dst.Get(1)
+		if node1.Value != 100 {
+			t.Fatalf("unexpected value %d", node1.Value)
+		}
+
+		srcNode7, _ := src.Get(7)
+		dstNode7, _ := dst.Get(7)
+		if srcNode7 != dstNode7 {
+			t.Fatal("unexpected copy of shared subtree")
+		}
+	})
+
+	t.Run("deep copy", func(t *testing.T) {
+		dst, src := build()
+		conflicts := soytrie.MergeCopy(dst, src, nil)
+		if conflicts != 2 {
+			t.Fatalf("unexpected conflicts %d", conflicts)
+		}
+
+		srcNode13, _ := src.Get(1, 3)
+		dstNode13, _ := dst.Get(1, 3)
+		if srcNode13 == dstNode13 {
+			t.Fatal("unexpected sharing of copied subtree")
+		}
+		_ = src.Insert(1345, 1, 3, 4, 5)
+		if dst.Search(soytrie.ModePrefix, 1, 3, 4, 5) {
+			t.Fatal("unexpected change to dst after changing src")
+		}
+		if !dst.Search(soytrie.ModeExact, 1, 3, 4) {
+			t.Fatal("missing copied value")
+		}
+	})
+
+	t.Run("valued only on one side", func(t *testing.T) {
+		dst := soytrie.New[int, int]()
+		_ = dst.Insert(12, 1, 2)
+		src := soytrie.New[int, int]()
+		_ = src.Insert(1, 1)
+		conflicts := soytrie.Merge(dst, src, func([]int, int, int) int {
+			t.Fatal("unexpected call to resolve")
+			return 0
+		})
+		if conflicts != 0 {
+			t.Fatalf("unexpected conflicts %d", conflicts)
+		}
+		if !dst.Search(soytrie.ModeExact, 1) || !dst.Search(soytrie.ModeExact, 1, 2) {
+			t.Fatal("missing merged values")
+		}
+	})
+}
+
+func TestMergeOrder(t *testing.T) {
+	// src is in insertion order, so its values are ascending,
+	// while dst is in key order, so the same values are descending
+	build := func(dstOrder *soytrie.Order[int]) (*soytrie.Node[int, int], *soytrie.Node[int, int]) {
+		dst := soytrie.New[int, int]()
+		dst.SetOrder(dstOrder)
+		_ = dst.Insert(1, 1)
+		src := soytrie.New[int, int]()
+		src.SetOrder(soytrie.OrderInsertion[int]())
+		for i := range 20 {
+			_ = src.Insert(i, 5, 19-i)
+		}
+		return dst, src
+	}
+	sorted := func(node *soytrie.Node[int, int], descending bool) bool {
+		values := valuesOf(node)
+		if descending {
+			slices.Reverse(values)
+		}
+		return len(values) == 20 && slices.IsSorted(values)
+	}
+
+	for _, mergeFn := range []func(dst, src *soytrie.Node[int, int], resolve func([]int, int, int) int) int{
+		soytrie.Merge[int, int],
+		soytrie.MergeCopy[int, int],
+	} {
+		dst, src := build(soytrie.OrderAscending[int]())
+		mergeFn(dst, src, nil)
+		merged, _ := dst.Get(5)
+		original, _ := src.Get(5)
+		if merged == original {
+			t.Fatalf("unexpected sharing with ordered dst")
+		}
+		for range 10 {
+			if !sorted(merged, true) {
+				t.Fatalf("unexpected merged values %v", valuesOf(merged))
+			}
+			if !sorted(original, false) {
+				t.Fatalf("unexpected src values %v", valuesOf(original))
+			}
+		}
+	}
+
+	// Without an Order on dst, Merge still shares
+	dst, src := build(nil)
+	soytrie.Merge(dst, src, nil)
+	merged, _ := dst.Get(5)
+	original, _ := src.Get(5)
+	if merged != original || !sorted(original, false) {
+		t.Fatalf("unexpected copy or reorder with unordered dst")
+	}
+}
+
+func TestMergeResolvePath(t *testing.T) {
+	dst, src := soytrie.New[int, int](), soytrie.New[int, int]()
+	for _, path := range [][]int{{1, 2}, {1, 3}, {4}} {
+		_ = dst.Insert(0, path[0], path[1:]...)
+		_ = src.Insert(1, path[0], path[1:]...)
+	}
+
+	paths := [][]int{}
+	soytrie.Merge(dst, src, func(path []int, a, b int) int {
+		paths = append(paths, slices.Clone(path))
+		return a + b
+	})
+	slices.SortFunc(paths, slices.Compare)
+	if expected := [][]int{{1, 2}, {1, 3}, {4}}; !slices.EqualFunc(paths, expected, slices.Equal) {
+		t.Fatalf("unexpected paths %v", paths)
+	}
+}
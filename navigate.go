@@ -0,0 +1,158 @@
+package soytrie
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Paths are ordered lexicographically: keys are compared one by one,
+// and a path comes before every path it is a prefix of.
+// The functions below ignore any Order set on the nodes.
+
+// Min returns the smallest valued path under n
+func Min[K cmp.Ordered, V any](n *Node[K, V]) (Entry[K, V], bool) {
+	return first(Range(n, nil, nil))
+}
+
+// Max returns the largest valued path under n
+func Max[K cmp.Ordered, V any](n *Node[K, V]) (Entry[K, V], bool) {
+	return first(RangeReverse(n, nil, nil))
+}
+
+// Next returns the smallest valued path under n that is greater than
+// path. The path itself does not have to exist.
+func Next[K cmp.Ordered, V any](n *Node[K, V], path ...K) (Entry[K, V], bool) {
+	for p, node := range Range(n, path, nil) {
+		if slices.Equal(p, path) {
+			continue
+		}
+		return Entry[K, V]{Path: slices.Clone(p), Value: node.Value, Valued: true}, true
+	}
+	return Entry[K, V]{}, false
+}
+
+// Prev returns the largest valued path under n that is less than
+// path. The path itself does not have to exist.
+func Prev[K cmp.Ordered, V any](n *Node[K, V], path ...K) (Entry[K, V], bool) {
+	if len(path) == 0 {
+		// Nothing comes before the root
+		return Entry[K, V]{}, false
+	}
+	return first(RangeReverse(n, nil, path))
+}
+
+// Range returns an iterator over valued nodes whose paths are
+// in [from, to), in ascending order. An empty to means no upper bound.
+// Only the subtrees that overlap the range are visited.
+//
+// The yielded path is only valid until the next iteration.
+func Range[K cmp.Ordered, V any](n *Node[K, V], from, to []K) iter.Seq2[[]K, *Node[K, V]] {
+	return func(yield func([]K, *Node[K, V]) bool) {
+		r := ranger[K, V]{from: from, to: to, yield: yield}
+		r.ascend(n, nil, true, len(to) != 0)
+	}
+}
+
+// RangeReverse is like Range, but yields in descending order
+func RangeReverse[K cmp.Ordered, V any](n *Node[K, V], from, to []K) iter.Seq2[[]K, *Node[K, V]] {
+	return func(yield func([]K, *Node[K, V]) bool) {
+		r := ranger[K, V]{from: from, to: to, yield: yield}
+		r.descend(n, nil, true, len(to) != 0)
+	}
+}
+
+func first[K comparable, V any](seq iter.Seq2[[]K, *Node[K, V]]) (Entry[K, V], bool) {
+	for path, node := range seq {
+		return Entry[K, V]{Path: slices.Clone(path), Value: node.Value, Valued: true}, true
+	}
+	return Entry[K, V]{}, false
+}
+
+// ranger walks the nodes between from and to. While walking,
+// lo reports whether path is a prefix of from (so children may
+// still be below the range), and hi whether path is a prefix of to.
+type ranger[K cmp.Ordered, V any] struct {
+	from, to []K
+	yield    func([]K, *Node[K, V]) bool
+}
+
+// inRange reports whether the node at path is in the range,
+// given that it was not cut off by to
+func (r *ranger[K, V]) inRange(path []K, lo bool) bool {
+	return !lo || len(path) >= len(r.from)
+}
+
+// bounds returns the lo and hi states of the child at key k,
+// and whether the child is below (-1) or above (+1) the range
+func (r *ranger[K, V]) bounds(d int, k K, lo, hi bool) (bool, bool, int) {
+	if lo && d < len(r.from) {
+		c := cmp.Compare(k, r.from[d])
+		if c < 0 {
+			return false, false, -1
+		}
+		lo = c == 0
+	} else {
+		lo = false
+	}
+	if hi {
+		c := cmp.Compare(k, r.to[d])
+		if c > 0 {
+			return false, false, 1
+		}
+		hi = c == 0
+	}
+	return lo, hi, 0
+}
+
+func (r *ranger[K, V]) ascend(node *Node[K, V], path []K, lo, hi bool) bool {
+	d := len(path)
+	if hi && d == len(r.to) {
+		// path equals to, so it and its descendants are out of range
+		return true
+	}
+	if node.Valued && r.inRange(path, lo) {
+		if !r.yield(path[:d:d], node) {
+			return false
+		}
+	}
+	for _, k := range slices.Sorted(maps.Keys(node.Children)) {
+		childLo, childHi, out := r.bounds(d, k, lo, hi)
+		if out < 0 {
+			continue
+		}
+		if out > 0 {
+			break
+		}
+		if !r.ascend(node.Children[k], append(path, k), childLo, childHi) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *ranger[K, V]) descend(node *Node[K, V], path []K, lo, hi bool) bool {
+	d := len(path)
+	if hi && d == len(r.to) {
+		return true
+	}
+	keys := slices.Sorted(maps.Keys(node.Children))
+	slices.Reverse(keys)
+	for _, k := range keys {
+		childLo, childHi, out := r.bounds(d, k, lo, hi)
+		if out > 0 {
+			continue
+		}
+		if out < 0 {
+			break
+		}
+		if !r.descend(node.Children[k], append(path, k), childLo, childHi) {
+			return false
+		}
+	}
+	if node.Valued && r.inRange(path, lo) {
+		return r.yield(path[:d:d], node)
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+package soytrie_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func TestMinMaxNextPrev(t *testing.T) {
+	root := soytrie.New[string, int]()
+	_ = root.Insert(1, "a")
+	_ = root.Insert(2, "a", "b")
+	_ = root.Insert(3, "a", "b", "c")
+	_ = root.Insert(4, "b", "a")
+	_ = root.Insert(5, "c")
+
+	if _, ok := soytrie.Min(soytrie.New[string, int]()); ok {
+		t.Fatalf("unexpected Min on empty trie")
+	}
+	if e, ok := soytrie.Min(root); !ok || e.Value != 1 || !slices.Equal(e.Path, []string{"a"}) {
+		t.Fatalf("unexpected Min %v %v", e, ok)
+	}
+	if e, ok := soytrie.Max(root); !ok || e.Value != 5 {
+		t.Fatalf("unexpected Max %v %v", e, ok)
+	}
+
+	tests := []struct {
+		path []string
+		next int // 0 if none
+		prev int
+	}{
+		{path: nil, next: 1, prev: 0},
+		{path: []string{"a"}, next: 2, prev: 0},
+		{path: []string{"a", "a"}, next: 2, prev: 1},
+		{path: []string{"a", "b", "c"}, next: 4, prev: 2},
+		{path: []string{"a", "z"}, next: 4, prev: 3},
+		{path: []string{"b"}, next: 4, prev: 3},
+		{path: []string{"b", "a"}, next: 5, prev: 3},
+		{path: []string{"c"}, next: 0, prev: 4},
+		{path: []string{"d"}, next: 0, prev: 5},
+	}
+	for _, tc := range tests {
+		next, ok := soytrie.Next(root, tc.path...)
+		if ok != (tc.next != 0) || next.Value != tc.next {
+			t.Fatalf("unexpected Next(%v) %v %v, expected %d", tc.path, next, ok, tc.next)
+		}
+		prev, ok := soytrie.Prev(root, tc.path...)
+		if ok != (tc.prev != 0) || prev.Value != tc.prev {
+			t.Fatalf("unexpected Prev(%v) %v %v, expected %d", tc.path, prev, ok, tc.prev)
+		}
+	}
+}
+
+func TestRange(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	randomPath := func() []int {
+		path := make([]int, rng.IntN(4))
+		for i := range path {
+			path[i] = rng.IntN(4)
+		}
+		return path
+	}
+
+	root := soytrie.New[int, int]()
+	paths := [][]int{}
+	for range 60 {
+		path := randomPath()
+		if len(path) == 0 || slices.ContainsFunc(paths, func(p []int) bool { return slices.Equal(p, path) }) {
+			continue
+		}
+		_ = root.Insert(len(paths), path[0], path[1:]...)
+		paths = append(paths, path)
+	}
+	slices.SortFunc(paths, slices.Compare)
+
+	for range 500 {
+		from, to := randomPath(), randomPath()
+		expected := [][]int{}
+		for _, p := range paths {
+			if slices.Compare(p, from) >= 0 && (len(to) == 0 || slices.Compare(p, to) < 0) {
+				expected = append(expected, p)
+			}
+		}
+
+		actual := [][]int{}
+		for path := range soytrie.Range(root, from, to) {
+			actual = append(actual, slices.Clone(path))
+		}
+		if !slices.EqualFunc(actual, expected, slices.Equal) {
+			t.Fatalf("unexpected Range(%v, %v) %v, expected %v", from, to, actual, expected)
+		}
+
+		actual = actual[:0]
+		for path := range soytrie.RangeReverse(root, from, to) {
+			actual = append(actual, slices.Clone(path))
+		}
+		slices.Reverse(expected)
+		if !slices.EqualFunc(actual, expected, slices.Equal) {
+			t.Fatalf("unexpected RangeReverse(%v, %v) %v, expected %v", from, to, actual, expected)
+		}
+	}
+
+	t.Run("stops early", func(t *testing.T) {
+		count := 0
+		for range soytrie.Range(root, nil, nil) {
+			count++
+			if count == 3 {
+				break
+			}
+		}
+		if count != 3 {
+			t.Fatalf("unexpected count %d", count)
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package soytrie
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"sync/atomic"
+)
+
+// Order decides the order in which a node's children are visited
+// by every collection and iteration API. Without an Order,
+// children are visited in Go map order, which varies between runs.
+//
+// An Order is set on a root with SetOrder, and is inherited
+// by children inserted later. Besides Node and the types built on it,
+// PersistentNode, RadixNode and WeightedTrie (for ties) support Order.
+type Order[K comparable] struct {
+	cmp func(a, b K) int
+
+	// insertion orders children by seq, which is taken
+	// from counter when a child is inserted
+	insertion bool
+	counter   atomic.Uint64
+}
+
+// OrderBy orders children by their keys, using cmp
+func OrderBy[K comparable](cmp func(a, b K) int) *Order[K] {
+	return &Order[K]{cmp: cmp}
+}
+
+// OrderAscending orders children by their keys, in ascending order
+func OrderAscending[K cmp.Ordered]() *Order[K] {
+	return OrderBy(cmp.Compare[K])
+}
+
+// OrderInsertion orders children by when they were inserted
+func OrderInsertion[K comparable]() *Order[K] {
+	return &Order[K]{insertion: true}
+}
+
+// SetOrder sets the order of n and its whole subtree.
+// With OrderInsertion, existing children are ordered as they
+// were last visited. A nil order restores Go map order.
+func (n *Node[K, V]) SetOrder(order *Order[K]) {
+	n.Walk(WalkPreOrder, func(_ []K, node *Node[K, V]) WalkAction {
+		node.order = order
+		if order != nil && order.insertion {
+			for _, child := range node.children() {
+				child.seq = order.next()
+			}
+		}
+		return WalkContinue
+	})
+}
+
+// adopt gives node, which is being inserted as a child of n,
+// n's order and a place among n's children
+func (n *Node[K, V]) adopt(node *Node[K, V]) {
+	if n.order == nil {
+		return
+	}
+	if len(node.Children) != 0 && node.order != n.order {
+		node.SetOrder(n.order)
+	}
+	node.order = n.order
+	node.seq = n.order.next()
+}
+
+// children returns an iterator over n's children in n's order
+func (n *Node[K, V]) children() iter.Seq2[K, *Node[K, V]] {
+	return orderedChildren(n.order, n.Children, nodeSeq[K, V])
+}
+
+// childKeys returns the keys of n's children, sorted by n's order
+func (n *Node[K, V]) childKeys() []K {
+	return orderedKeys(n.order, n.Children, nodeSeq[K, V])
+}
+
+func nodeSeq[K comparable, V any](n *Node[K, V]) uint64 {
+	return n.seq
+}
+
+// next returns the next insertion sequence number, or 0
+// if order is not an insertion order
+func (order *Order[K]) next() uint64 {
+	if order == nil || !order.insertion {
+		return 0
+	}
+	return order.counter.Add(1)
+}
+
+// orderedChildren returns an iterator over children in order,
+// where seq returns a child's insertion sequence number.
+// This is shared by every node type that supports Order.
+func orderedChildren[K comparable, C any](order *Order[K], children map[K]C, seq func(C) uint64) iter.Seq2[K, C] {
+	return func(yield func(K, C) bool) {
+		if order == nil {
+			for k, child := range children {
+				if !yield(k, child) {
+					return
+				}
+			}
+			return
+		}
+		for _, k := range orderedKeys(order, children, seq) {
+			if !yield(k, children[k]) {
+				return
+			}
+		}
+	}
+}
+
+// orderedKeys returns the keys of children, sorted by order
+func orderedKeys[K comparable, C any](order *Order[K], children map[K]C, seq func(C) uint64) []K {
+	keys := make([]K, 0, len(children))
+	for k := range children {
+		keys = append(keys, k)
+	}
+	switch {
+	case order == nil:
+	case order.insertion:
+		slices.SortFunc(keys, func(a, b K) int {
+			return cmp.Compare(seq(children[a]), seq(children[b]))
+		})
+	default:
+		slices.SortFunc(keys, order.cmp)
+	}
+	return keys
+}
@@ -0,0 +1,218 @@
+package soytrie_test
+
+import (
+	"cmp"
+	"encoding/json"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func valuesOf[K comparable](root *soytrie.Node[K, int]) []int {
+	values := []int{}
+	for _, node := range root.Values() {
+		values = append(values, node.Value)
+	}
+	return values
+}
+
+func TestOrderAscending(t *testing.T) {
+	root := soytrie.New[string, int]()
+	root.SetOrder(soytrie.OrderAscending[string]())
+	_ = root.Insert(3, "c")
+	_ = root.Insert(1, "a")
+	_ = root.Insert(4, "c", "b")
+	_ = root.Insert(5, "c", "a")
+	_ = root.Insert(2, "b")
+
+	expected := []int{1, 2, 3, 5, 4}
+	for range 20 {
+		if actual := valuesOf(root); !slices.Equal(actual, expected) {
+			t.Fatalf("unexpected values %v, expected %v", actual, expected)
+		}
+	}
+
+	entries, ok := root.PredictPaths(soytrie.ModePrefix, 0, "c")
+	if !ok || len(entries) != 3 {
+		t.Fatalf("unexpected PredictPaths result %v %v", entries, ok)
+	}
+	for i, path := range [][]string{{"c"}, {"c", "a"}, {"c", "b"}} {
+		if !slices.Equal(entries[i].Path, path) {
+			t.Fatalf("unexpected path %v at %d, expected %v", entries[i].Path, i, path)
+		}
+	}
+
+	t.Run("walk", func(t *testing.T) {
+		expected := map[soytrie.WalkOrder][]int{
+			soytrie.WalkPreOrder:   {1, 2, 3, 5, 4},
+			soytrie.WalkPostOrder:  {1, 2, 5, 4, 3},
+			soytrie.WalkLevelOrder: {1, 2, 3, 5, 4},
+		}
+		for order, values := range expected {
+			actual := []int{}
+			root.Walk(order, func(_ []string, node *soytrie.Node[string, int]) soytrie.WalkAction {
+				if node.Valued {
+					actual = append(actual, node.Value)
+				}
+				return soytrie.WalkContinue
+			})
+			if !slices.Equal(actual, values) {
+				t.Fatalf("[order %d] unexpected values %v, expected %v", order, actual, values)
+			}
+		}
+	})
+
+	t.Run("clone", func(t *testing.T) {
+		clone := root.Clone()
+		_ = clone.Insert(0, "0")
+		if actual := valuesOf(clone); !slices.Equal(actual, []int{0, 1, 2, 3, 5, 4}) {
+			t.Fatalf("unexpected clone values %v", actual)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		b, err := json.Marshal(root)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		decoded := soytrie.New[string, int]()
+		decoded.SetOrder(soytrie.OrderAscending[string]())
+		err = json.Unmarshal(b, decoded)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if actual := valuesOf(decoded); !slices.Equal(actual, expected) {
+			t.Fatalf("unexpected decoded values %v", actual)
+		}
+	})
+}
+
+func TestOrderBy(t *testing.T) {
+	trie := soytrie.NewTrie[string, int]()
+	trie.SetOrder(soytrie.OrderBy(func(a, b string) int {
+		return cmp.Compare(b, a)
+	}))
+	trie.Insert(1, "a")
+	trie.Insert(2, "b")
+	trie.Insert(3, "b", "a")
+	trie.Insert(4, "b", "z")
+
+	actual := []int{}
+	for _, v := range trie.Values() {
+		actual = append(actual, v)
+	}
+	if expected := []int{2, 4, 3, 1}; !slices.Equal(actual, expected) {
+		t.Fatalf("unexpected values %v, expected %v", actual, expected)
+	}
+}
+
+func TestOrderInsertion(t *testing.T) {
+	root := soytrie.New[string, int]()
+	root.SetOrder(soytrie.OrderInsertion[string]())
+	_ = root.Insert(1, "z")
+	_ = root.Insert(2, "a")
+	_ = root.Insert(3, "m")
+	_ = root.Insert(4, "a", "y")
+	_ = root.Insert(5, "a", "b")
+
+	if actual := valuesOf(root); !slices.Equal(actual, []int{1, 2, 4, 5, 3}) {
+		t.Fatalf("unexpected values %v", actual)
+	}
+
+	// Overwriting keeps the position, re-inserting moves to the back
+	_ = root.Insert(10, "z")
+	_, _ = root.Remove("a")
+	_ = root.Insert(20, "a")
+	if actual := valuesOf(root); !slices.Equal(actual, []int{10, 3, 20}) {
+		t.Fatalf("unexpected values %v", actual)
+	}
+}
+
+func TestSetOrderExisting(t *testing.T) {
+	root := soytrie.New[int, int]()
+	for i := range 100 {
+		_ = root.Insert(i, i%10, i)
+	}
+	root.SetOrder(soytrie.OrderAscending[int]())
+
+	actual := valuesOf(root)
+	if !slices.IsSortedFunc(actual, func(a, b int) int {
+		return cmp.Or(cmp.Compare(a%10, b%10), cmp.Compare(a, b))
+	}) {
+		t.Fatalf("unexpected values %v", actual)
+	}
+
+	// A nil order goes back to map order, but still visits everything
+	root.SetOrder(nil)
+	if count := len(valuesOf(root)); count != 100 {
+		t.Fatalf("unexpected count %d", count)
+	}
+}
+
+// orderedPaths inserts paths into a Node, a PersistentNode and a RadixNode
+// with the same order, and returns the paths each one predicts
+func orderedPaths(order func() *soytrie.Order[int], paths [][]int) [3][][]int {
+	node := soytrie.New[int, int]()
+	node.SetOrder(order())
+	persistent := soytrie.NewPersistent[int, int]().SetOrder(order())
+	radix := soytrie.NewRadix[int, int]()
+	radix.SetOrder(order())
+	for i, path := range paths {
+		_ = node.Insert(i, path[0], path[1:]...)
+		persistent = persistent.Insert(i, path[0], path[1:]...)
+		_ = radix.Insert(i, path[0], path[1:]...)
+	}
+
+	var results [3][][]int
+	nodeEntries, _ := node.PredictPaths(soytrie.ModePrefix, 0)
+	persistentEntries, _ := persistent.Predict(soytrie.ModePrefix)
+	radixEntries, _ := radix.Predict(soytrie.ModePrefix)
+	for i, entries := range [][]soytrie.Entry[int, int]{nodeEntries, persistentEntries, radixEntries} {
+		for _, e := range entries {
+			results[i] = append(results[i], e.Path)
+		}
+	}
+	return results
+}
+
+func TestOrderNodeTypes(t *testing.T) {
+	rng := rand.New(rand.NewPCG(9, 10))
+	orders := map[string]func() *soytrie.Order[int]{
+		"ascending": soytrie.OrderAscending[int],
+		"descending": func() *soytrie.Order[int] {
+			return soytrie.OrderBy(func(a, b int) int { return cmp.Compare(b, a) })
+		},
+		"insertion": soytrie.OrderInsertion[int],
+	}
+	for name, order := range orders {
+		for range 50 {
+			paths := [][]int{}
+			for range 1 + rng.IntN(10) {
+				path := make([]int, 1+rng.IntN(5))
+				for i := range path {
+					path[i] = rng.IntN(4)
+				}
+				paths = append(paths, path)
+			}
+			results := orderedPaths(order, paths)
+			for i := 1; i < len(results); i++ {
+				if !slices.EqualFunc(results[0], results[i], slices.Equal) {
+					t.Fatalf("[%s] unexpected paths %v, expected %v", name, results[i], results[0])
+				}
+			}
+		}
+	}
+
+	t.Run("persistent versions", func(t *testing.T) {
+		v1 := soytrie.NewPersistent[int, int]().Insert(2, 2).Insert(1, 1)
+		v2 := v1.SetOrder(soytrie.OrderBy(func(a, b int) int { return cmp.Compare(b, a) }))
+		for range 10 {
+			entries, _ := v2.Insert(3, 3).Predict(soytrie.ModeExact)
+			if len(entries) != 3 || entries[0].Value != 3 || entries[2].Value != 1 {
+				t.Fatalf("unexpected entries %v", entries)
+			}
+		}
+	})
+}
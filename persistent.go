@@ -0,0 +1,168 @@
+package soytrie
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// PersistentNode is an immutable trie node. Mutating methods
+// return a new root, copying only the nodes along the modified path
+// and sharing every other node with the previous version.
+//
+// Because no version is ever modified in place, a root can be read
+// by any number of goroutines without locks, and writers can publish
+// new versions with atomic.Pointer.
+type PersistentNode[K comparable, V any] struct {
+	value    V
+	valued   bool
+	children map[K]*PersistentNode[K, V]
+
+	order *Order[K]
+	seq   uint64
+}
+
+func NewPersistent[K comparable, V any]() *PersistentNode[K, V] {
+	return &PersistentNode[K, V]{}
+}
+
+// Value returns the node's value, if any
+func (n *PersistentNode[K, V]) Value() (V, bool) {
+	return n.value, n.valued
+}
+
+func (n *PersistentNode[K, V]) GetDirect(k K) (*PersistentNode[K, V], bool) {
+	child, ok := n.children[k]
+	return child, ok
+}
+
+func (n *PersistentNode[K, V]) Get(path ...K) (*PersistentNode[K, V], bool) {
+	curr := n
+	for i := range path {
+		next, ok := curr.GetDirect(path[i])
+		if !ok {
+			return nil, false
+		}
+		curr = next
+	}
+	return curr, true
+}
+
+func (n *PersistentNode[K, V]) Search(mode Mode, path ...K) bool {
+	target, ok := n.Get(path...)
+	if !ok {
+		return false
+	}
+	if mode == ModePrefix {
+		return true
+	}
+	return target.valued
+}
+
+// Predict returns entries under path with their full paths.
+// See Node.Predict for the meaning of mode.
+func (n *PersistentNode[K, V]) Predict(mode Mode, path ...K) ([]Entry[K, V], bool) {
+	target, ok := n.Get(path...)
+	if !ok {
+		return nil, false
+	}
+	entries := []Entry[K, V]{}
+	target.collect(mode, slices.Clone(path), &entries)
+	return entries, true
+}
+
+func (n *PersistentNode[K, V]) collect(mode Mode, path []K, entries *[]Entry[K, V]) {
+	if mode == ModePrefix || n.valued {
+		*entries = append(*entries, Entry[K, V]{
+			Path:   slices.Clone(path),
+			Value:  n.value,
+			Valued: n.valued,
+		})
+	}
+	for k, child := range n.inOrder() {
+		child.collect(mode, append(path, k), entries)
+	}
+}
+
+// SetOrder returns a new root with order set on every node,
+// which is inherited by nodes inserted later. See Node.SetOrder.
+// Unlike other mutations, it copies the whole trie.
+func (n *PersistentNode[K, V]) SetOrder(order *Order[K]) *PersistentNode[K, V] {
+	c := n.clone()
+	c.order = order
+	for k, child := range n.inOrder() {
+		copied := child.SetOrder(order)
+		copied.seq = order.next()
+		c.children[k] = copied
+	}
+	return c
+}
+
+// inOrder returns an iterator over n's children in n's order
+func (n *PersistentNode[K, V]) inOrder() iter.Seq2[K, *PersistentNode[K, V]] {
+	return orderedChildren(n.order, n.children, func(child *PersistentNode[K, V]) uint64 {
+		return child.seq
+	})
+}
+
+// Insert returns a new root with v at p0+pRest
+func (n *PersistentNode[K, V]) Insert(v V, p0 K, pRest ...K) *PersistentNode[K, V] {
+	return n.insert(v, append([]K{p0}, pRest...))
+}
+
+// InsertNoOverwrite is like Insert, but fails
+// if the insertion would overwrite an existing value
+func (n *PersistentNode[K, V]) InsertNoOverwrite(v V, p0 K, pRest ...K) (*PersistentNode[K, V], error) {
+	path := append([]K{p0}, pRest...)
+	if old, ok := n.Get(path...); ok && old.valued {
+		return nil, fmt.Errorf("valued node exists: %v", old.value)
+	}
+	return n.insert(v, path), nil
+}
+
+func (n *PersistentNode[K, V]) insert(v V, path []K) *PersistentNode[K, V] {
+	c := n.clone()
+	if len(path) == 0 {
+		c.value, c.valued = v, true
+		return c
+	}
+	child, ok := n.children[path[0]]
+	if !ok {
+		child = &PersistentNode[K, V]{order: n.order, seq: n.order.next()}
+	}
+	if c.children == nil {
+		c.children = make(map[K]*PersistentNode[K, V])
+	}
+	c.children[path[0]] = child.insert(v, path[1:])
+	return c
+}
+
+// Remove returns a new root without the subtree at path.
+// If path does not exist, n itself is returned with false.
+func (n *PersistentNode[K, V]) Remove(path ...K) (*PersistentNode[K, V], bool) {
+	if len(path) == 0 {
+		return n, false
+	}
+	if _, ok := n.Get(path...); !ok {
+		return n, false
+	}
+	return n.remove(path), true
+}
+
+func (n *PersistentNode[K, V]) remove(path []K) *PersistentNode[K, V] {
+	c := n.clone()
+	if len(path) == 1 {
+		delete(c.children, path[0])
+		return c
+	}
+	c.children[path[0]] = n.children[path[0]].remove(path[1:])
+	return c
+}
+
+// clone returns a shallow copy of n, with its own children map
+func (n *PersistentNode[K, V]) clone() *PersistentNode[K, V] {
+	c := *n
+	c.children = maps.Clone(n.children)
+	return &c
+}
@@ -0,0 +1,127 @@
+package soytrie_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func TestPersistent(t *testing.T) {
+	v0 := soytrie.NewPersistent[int, string]()
+	v1 := v0.Insert("1,2,3", 1, 2, 3)
+	v2 := v1.Insert("1,2", 1, 2)
+	v3 := v2.Insert("1,5", 1, 5)
+
+	if v0.Search(soytrie.ModePrefix, 1) {
+		t.Fatal("unexpected mutation of v0")
+	}
+	if v1.Search(soytrie.ModeExact, 1, 2) {
+		t.Fatal("unexpected mutation of v1")
+	}
+	if !v2.Search(soytrie.ModeExact, 1, 2) || !v2.Search(soytrie.ModeExact, 1, 2, 3) {
+		t.Fatal("unexpected missing values in v2")
+	}
+	if v2.Search(soytrie.ModePrefix, 1, 5) {
+		t.Fatal("unexpected mutation of v2")
+	}
+
+	t.Run("structural sharing", func(t *testing.T) {
+		node12v2, _ := v2.Get(1, 2)
+		node12v3, _ := v3.Get(1, 2)
+		if node12v2 != node12v3 {
+			t.Fatal("unexpected copy of untouched subtree")
+		}
+		node1v2, _ := v2.Get(1)
+		node1v3, _ := v3.Get(1)
+		if node1v2 == node1v3 {
+			t.Fatal("unexpected sharing of node along modified path")
+		}
+	})
+
+	t.Run("InsertNoOverwrite", func(t *testing.T) {
+		if _, err := v3.InsertNoOverwrite("new", 1, 2); err == nil {
+			t.Fatal("unexpected nil error")
+		}
+		v4, err := v3.InsertNoOverwrite("1", 1)
+		if err != nil {
+			t.Fatal("unexpected error", err)
+		}
+		node, ok := v4.Get(1)
+		if !ok {
+			t.Fatal("unexpected false")
+		}
+		if v, valued := node.Value(); !valued || v != "1" {
+			t.Fatalf("unexpected value '%s'", v)
+		}
+		if v3.Search(soytrie.ModeExact, 1) {
+			t.Fatal("unexpected mutation of v3")
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		v4, ok := v3.Remove(1, 2)
+		if !ok {
+			t.Fatal("unexpected false")
+		}
+		if v4.Search(soytrie.ModePrefix, 1, 2) {
+			t.Fatal("unexpected subtree after Remove")
+		}
+		if !v3.Search(soytrie.ModeExact, 1, 2, 3) {
+			t.Fatal("unexpected mutation of v3")
+		}
+		if same, ok := v4.Remove(7); ok || same != v4 {
+			t.Fatal("unexpected Remove result for missing path")
+		}
+	})
+
+	t.Run("Predict", func(t *testing.T) {
+		entries, ok := v3.Predict(soytrie.ModeExact, 1)
+		if !ok {
+			t.Fatal("unexpected false")
+		}
+		if l := len(entries); l != 3 {
+			t.Fatalf("unexpected length %d", l)
+		}
+		entries, ok = v3.Predict(soytrie.ModePrefix, 1)
+		if !ok {
+			t.Fatal("unexpected false")
+		}
+		if l := len(entries); l != 4 {
+			t.Fatalf("unexpected length %d", l)
+		}
+	})
+
+	t.Run("atomic publish", func(t *testing.T) {
+		var current atomic.Pointer[soytrie.PersistentNode[int, int]]
+		current.Store(soytrie.NewPersistent[int, int]())
+
+		var wg sync.WaitGroup
+		var writer sync.Mutex
+		for w := range 4 {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for i := range 50 {
+					writer.Lock()
+					current.Store(current.Load().Insert(i, w, i))
+					writer.Unlock()
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for range 50 {
+					snapshot := current.Load()
+					_, _ = snapshot.Predict(soytrie.ModeExact, w)
+				}
+			}()
+		}
+		wg.Wait()
+
+		entries, _ := current.Load().Predict(soytrie.ModeExact)
+		if l := len(entries); l != 200 {
+			t.Fatalf("unexpected length %d", l)
+		}
+	})
+}
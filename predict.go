@@ -0,0 +1,65 @@
+package soytrie
+
+import "slices"
+
+// Entry is a node's path paired with its value
+type Entry[K comparable, V any] struct {
+	Path   []K
+	Value  V
+	Valued bool
+}
+
+// PredictOption modifies how PredictPaths reports its results
+type PredictOption uint8
+
+const (
+	// PredictSuffix reports paths relative to the query path
+	// instead of full paths from the receiver
+	PredictSuffix PredictOption = 1 << iota
+
+	// PredictExcludeSelf leaves out the node at the query path
+	PredictExcludeSelf
+)
+
+// PredictPaths is like Predict, but returns each match as an Entry
+// carrying the match's path.
+func (n *Node[K, V]) PredictPaths(mode Mode, opts PredictOption, path ...K) ([]Entry[K, V], bool) {
+	target, ok := n.Get(path...)
+	if !ok {
+		return nil, false
+	}
+
+	var testFn func(*Node[K, V]) bool
+	if mode == ModeExact {
+		testFn = isValued[K, V]
+	}
+
+	prefix := path
+	if opts&PredictSuffix != 0 {
+		prefix = nil
+	}
+	buf := make([]K, len(prefix), len(prefix)+8)
+	copy(buf, prefix)
+
+	entries := []Entry[K, V]{}
+	walkSeq(testFn, target, buf, func(p []K, node *Node[K, V]) bool {
+		if node == target && opts&PredictExcludeSelf != 0 {
+			return true
+		}
+		entries = append(entries, Entry[K, V]{
+			Path:   slices.Clone(p),
+			Value:  node.Value,
+			Valued: node.Valued,
+		})
+		return true
+	})
+
+	return entries, true
+}
+
+// Completions returns the valued completions of path,
+// reported as suffixes relative to path. The node at path itself
+// is not a completion.
+func (n *Node[K, V]) Completions(path ...K) ([]Entry[K, V], bool) {
+	return n.PredictPaths(ModeExact, PredictSuffix|PredictExcludeSelf, path...)
+}
@@ -0,0 +1,125 @@
+package soytrie_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func TestPredictPaths(t *testing.T) {
+	root := soytrie.New[int, string]()
+	_ = root.Insert("1", 1)
+	_ = root.Insert("1,2", 1, 2)
+	_ = root.Insert("1,2,3", 1, 2, 3)
+	_ = root.Insert("1,5,6", 1, 5, 6)
+	_ = root.Insert("2,3", 2, 3)
+
+	type testCase struct {
+		path        []int
+		mode        soytrie.Mode
+		opts        soytrie.PredictOption
+		expectedOk  bool
+		expectedLen int
+	}
+
+	tests := []testCase{
+		{
+			path:        []int{1},
+			mode:        soytrie.ModeExact,
+			expectedOk:  true,
+			expectedLen: 4,
+		},
+		{
+			path:        []int{1},
+			mode:        soytrie.ModeExact,
+			opts:        soytrie.PredictExcludeSelf,
+			expectedOk:  true,
+			expectedLen: 3,
+		},
+		{
+			path:        []int{1},
+			mode:        soytrie.ModePrefix,
+			expectedOk:  true,
+			expectedLen: 5,
+		},
+		{
+			path:        []int{1},
+			mode:        soytrie.ModePrefix,
+			opts:        soytrie.PredictExcludeSelf | soytrie.PredictSuffix,
+			expectedOk:  true,
+			expectedLen: 4,
+		},
+		{
+			path:        []int{2},
+			mode:        soytrie.ModeExact,
+			expectedOk:  true,
+			expectedLen: 1,
+		},
+		{
+			path:        []int{2, 4},
+			mode:        soytrie.ModePrefix,
+			expectedOk:  false,
+			expectedLen: 0,
+		},
+	}
+
+	for i := range tests {
+		tc := &tests[i]
+		actual, ok := root.PredictPaths(tc.mode, tc.opts, tc.path...)
+		if ok != tc.expectedOk {
+			t.Fatalf("[case %d] unexpected ok, expecting=%v, actual=%v", i, tc.expectedOk, ok)
+		}
+		if len(actual) != tc.expectedLen {
+			t.Fatalf("[case %d] unexpected len %d, expecting %d", i, len(actual), tc.expectedLen)
+		}
+
+		for j := range actual {
+			entry := &actual[j]
+			full := entry.Path
+			if tc.opts&soytrie.PredictSuffix != 0 {
+				full = append(slices.Clone(tc.path), entry.Path...)
+			}
+			node, ok := root.Get(full...)
+			if !ok {
+				t.Fatalf("[case %d] missing node for entry path %v", i, entry.Path)
+			}
+			if node.Valued != entry.Valued || node.Value != entry.Value {
+				t.Fatalf("[case %d] unexpected entry %+v for path %v", i, entry, full)
+			}
+			if tc.mode == soytrie.ModeExact && !entry.Valued {
+				t.Fatalf("[case %d] unexpected non-valued entry %+v", i, entry)
+			}
+		}
+	}
+}
+
+func TestCompletions(t *testing.T) {
+	root := soytrie.New[rune, string]()
+	for _, word := range []string{"car", "cart", "care", "cat", "dog"} {
+		r := []rune(word)
+		_ = root.Insert(word, r[0], r[1:]...)
+	}
+
+	completions, ok := root.Completions([]rune("car")...)
+	if !ok {
+		t.Fatal("unexpected false")
+	}
+
+	suffixes := []string{}
+	for i := range completions {
+		c := &completions[i]
+		suffixes = append(suffixes, string(c.Path))
+		if c.Value != "car"+string(c.Path) {
+			t.Fatalf("unexpected value %s for suffix %s", c.Value, string(c.Path))
+		}
+	}
+	slices.Sort(suffixes)
+	if expected := []string{"e", "t"}; !slices.Equal(expected, suffixes) {
+		t.Fatalf("unexpected suffixes %v, expecting %v", suffixes, expected)
+	}
+
+	if _, ok := root.Completions([]rune("cow")...); ok {
+		t.Fatal("unexpected true")
+	}
+}
@@ -0,0 +1,48 @@
+package soytrie
+
+import "iter"
+
+// LongestPrefix returns the deepest valued node along path,
+// and the length of path matched by that node.
+// The receiver itself counts as a match of length 0 if it is valued.
+func (n *Node[K, V]) LongestPrefix(path ...K) (*Node[K, V], int, bool) {
+	var match *Node[K, V]
+	matched := 0
+	for p, node := range n.AllPrefixes(path...) {
+		match, matched = node, len(p)
+	}
+	if match == nil {
+		return nil, 0, false
+	}
+	return match, matched, true
+}
+
+// ShortestPrefix returns the shallowest valued node along path,
+// and the length of path matched by that node.
+func (n *Node[K, V]) ShortestPrefix(path ...K) (*Node[K, V], int, bool) {
+	for p, node := range n.AllPrefixes(path...) {
+		return node, len(p), true
+	}
+	return nil, 0, false
+}
+
+// AllPrefixes returns an iterator over every valued node along path,
+// from the shallowest to the deepest, paired with its prefix of path.
+func (n *Node[K, V]) AllPrefixes(path ...K) iter.Seq2[[]K, *Node[K, V]] {
+	return func(yield func([]K, *Node[K, V]) bool) {
+		curr := n
+		for i := 0; ; i++ {
+			if curr.Valued && !yield(path[:i:i], curr) {
+				return
+			}
+			if i == len(path) {
+				return
+			}
+			next, ok := curr.GetDirect(path[i])
+			if !ok {
+				return
+			}
+			curr = next
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package soytrie_test
+
+import (
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func TestLongestPrefix(t *testing.T) {
+	root := soytrie.New[string, string]()
+	_ = root.Insert("10", "10")
+	_ = root.Insert("10.1", "10", "1")
+	_ = root.Insert("10.1.2.3", "10", "1", "2", "3")
+	_ = root.Insert("192.168", "192", "168")
+
+	type testCase struct {
+		path            []string
+		expectedOk      bool
+		expectedLongest string
+		expectedLenL    int
+		expectedShort   string
+		expectedLenS    int
+		expectedCount   int
+	}
+
+	tests := []testCase{
+		{
+			path:            []string{"10", "1", "2", "3", "4"},
+			expectedOk:      true,
+			expectedLongest: "10.1.2.3",
+			expectedLenL:    4,
+			expectedShort:   "10",
+			expectedLenS:    1,
+			expectedCount:   3,
+		},
+		{
+			path:            []string{"10", "1", "2"},
+			expectedOk:      true,
+			expectedLongest: "10.1",
+			expectedLenL:    2,
+			expectedShort:   "10",
+			expectedLenS:    1,
+			expectedCount:   2,
+		},
+		{
+			path:            []string{"10", "9"},
+			expectedOk:      true,
+			expectedLongest: "10",
+			expectedLenL:    1,
+			expectedShort:   "10",
+			expectedLenS:    1,
+			expectedCount:   1,
+		},
+		{
+			path:            []string{"192", "168", "1", "1"},
+			expectedOk:      true,
+			expectedLongest: "192.168",
+			expectedLenL:    2,
+			expectedShort:   "192.168",
+			expectedLenS:    2,
+			expectedCount:   1,
+		},
+		{
+			path:       []string{"192", "169"},
+			expectedOk: false,
+		},
+		{
+			path:       []string{"172"},
+			expectedOk: false,
+		},
+		{
+			path:       []string{},
+			expectedOk: false,
+		},
+	}
+
+	for i := range tests {
+		tc := &tests[i]
+		longest, l, ok := root.LongestPrefix(tc.path...)
+		if ok != tc.expectedOk {
+			t.Fatalf("[case %d] unexpected ok %v, expecting %v", i, ok, tc.expectedOk)
+		}
+		shortest, s, ok := root.ShortestPrefix(tc.path...)
+		if ok != tc.expectedOk {
+			t.Fatalf("[case %d] unexpected ok %v, expecting %v", i, ok, tc.expectedOk)
+		}
+		count := 0
+		for prefix, node := range root.AllPrefixes(tc.path...) {
+			if actual, ok := root.Get(prefix...); !ok || actual != node {
+				t.Fatalf("[case %d] unexpected node for prefix %v", i, prefix)
+			}
+			count++
+		}
+		if count != tc.expectedCount {
+			t.Fatalf("[case %d] unexpected count %d, expecting %d", i, count, tc.expectedCount)
+		}
+		if !tc.expectedOk {
+			continue
+		}
+		if longest.Value != tc.expectedLongest || l != tc.expectedLenL {
+			t.Fatalf("[case %d] unexpected longest match %s (%d)", i, longest.Value, l)
+		}
+		if shortest.Value != tc.expectedShort || s != tc.expectedLenS {
+			t.Fatalf("[case %d] unexpected shortest match %s (%d)", i, shortest.Value, s)
+		}
+	}
+
+	t.Run("valued root", func(t *testing.T) {
+		root := soytrie.NewWithValue[string]("default")
+		_ = root.Insert("a", "a")
+		node, l, ok := root.LongestPrefix("b", "c")
+		if !ok || l != 0 || node != root {
+			t.Fatalf("unexpected root match %v %d %v", node, l, ok)
+		}
+		node, l, ok = root.LongestPrefix("a", "c")
+		if !ok || l != 1 || node.Value != "a" {
+			t.Fatalf("unexpected match %v %d %v", node, l, ok)
+		}
+	})
+}
@@ -0,0 +1,265 @@
+package soytrie
+
+import (
+	"iter"
+	"slices"
+)
+
+// RadixNode is a path-compressed trie node. Instead of one node
+// per key, runs of keys without branches are stored as a single edge.
+//
+// RadixNode has the same semantics as Node: every prefix of an inserted
+// path exists, even if it ends in the middle of a compressed edge.
+type RadixNode[K comparable, V any] struct {
+	value    V
+	valued   bool
+	children map[K]*radixEdge[K, V] // keyed by label[0]
+	order    *Order[K]
+}
+
+type radixEdge[K comparable, V any] struct {
+	label []K
+	node  *RadixNode[K, V]
+	seq   uint64
+}
+
+func NewRadix[K comparable, V any]() *RadixNode[K, V] {
+	return &RadixNode[K, V]{}
+}
+
+// Value returns the node's value, if any
+func (n *RadixNode[K, V]) Value() (V, bool) {
+	return n.value, n.valued
+}
+
+// NodeCount returns the number of nodes under n, excluding n
+func (n *RadixNode[K, V]) NodeCount() int {
+	count := 0
+	for _, e := range n.children {
+		count += 1 + e.node.NodeCount()
+	}
+	return count
+}
+
+// locate walks path from n. If path ends at a node, it returns
+// that node with a nil edge. If path ends inside an edge,
+// it returns the node the edge leaves from, the edge,
+// and the number of the edge's keys matched.
+func (n *RadixNode[K, V]) locate(path []K) (*RadixNode[K, V], *radixEdge[K, V], int, bool) {
+	curr := n
+	for len(path) != 0 {
+		e, ok := curr.children[path[0]]
+		if !ok {
+			return nil, nil, 0, false
+		}
+		m := commonPrefix(e.label, path)
+		if m == len(e.label) {
+			curr, path = e.node, path[m:]
+			continue
+		}
+		if m == len(path) {
+			return curr, e, m, true
+		}
+		return nil, nil, 0, false
+	}
+	return curr, nil, 0, true
+}
+
+// Get returns the value at path, if any. Get never changes the trie:
+// a path ending inside a compressed edge has no value.
+func (n *RadixNode[K, V]) Get(path ...K) (V, bool) {
+	node, e, _, ok := n.locate(path)
+	if !ok || e != nil || !node.valued {
+		var zero V
+		return zero, false
+	}
+	return node.value, true
+}
+
+func (n *RadixNode[K, V]) Search(mode Mode, path ...K) bool {
+	node, e, _, ok := n.locate(path)
+	if !ok {
+		return false
+	}
+	if mode == ModePrefix {
+		return true
+	}
+	return e == nil && node.valued
+}
+
+// Predict returns entries under path with their full paths.
+// In ModePrefix, every prefix is reported, including those
+// inside compressed edges, so results match Node.Predict.
+func (n *RadixNode[K, V]) Predict(mode Mode, path ...K) ([]Entry[K, V], bool) {
+	node, e, m, ok := n.locate(path)
+	if !ok {
+		return nil, false
+	}
+	entries := []Entry[K, V]{}
+	buf := slices.Clone(path)
+	if e == nil {
+		node.collect(mode, buf, &entries)
+		return entries, true
+	}
+
+	// Path ended inside an edge: report the rest of the edge,
+	// then the subtree the edge leads to
+	if mode == ModePrefix {
+		entries = append(entries, Entry[K, V]{Path: slices.Clone(buf)})
+	}
+	e.collect(mode, m, buf, &entries)
+	return entries, true
+}
+
+func (n *RadixNode[K, V]) collect(mode Mode, path []K, entries *[]Entry[K, V]) {
+	if mode == ModePrefix || n.valued {
+		*entries = append(*entries, Entry[K, V]{
+			Path:   slices.Clone(path),
+			Value:  n.value,
+			Valued: n.valued,
+		})
+	}
+	for _, e := range n.inOrder() {
+		e.collect(mode, 0, path, entries)
+	}
+}
+
+// SetOrder sets the order of n and its whole subtree.
+// Edges are ordered by their first keys. See Node.SetOrder.
+func (n *RadixNode[K, V]) SetOrder(order *Order[K]) {
+	n.order = order
+	for _, e := range n.inOrder() {
+		e.seq = order.next()
+		e.node.SetOrder(order)
+	}
+}
+
+// inOrder returns an iterator over n's edges in n's order
+func (n *RadixNode[K, V]) inOrder() iter.Seq2[K, *radixEdge[K, V]] {
+	return orderedChildren(n.order, n.children, func(e *radixEdge[K, V]) uint64 {
+		return e.seq
+	})
+}
+
+// collect collects entries for the edge's keys after
+// the first matched keys, and then the edge's node
+func (e *radixEdge[K, V]) collect(mode Mode, matched int, path []K, entries *[]Entry[K, V]) {
+	last := len(e.label) - 1
+	for i := matched; i < last; i++ {
+		path = append(path, e.label[i])
+		if mode == ModePrefix {
+			*entries = append(*entries, Entry[K, V]{Path: slices.Clone(path)})
+		}
+	}
+	e.node.collect(mode, append(path, e.label[last]), entries)
+}
+
+// Unique returns whether the path is a unique path
+// or a prefix to a valued node.
+func (n *RadixNode[K, V]) Unique(path ...K) bool {
+	entries, ok := n.Predict(ModeExact, path...)
+	if !ok {
+		return false
+	}
+	return len(entries) == 1
+}
+
+// Insert inserts v to p0+pRest, splitting edges as needed,
+// and returns the node holding v.
+func (n *RadixNode[K, V]) Insert(v V, p0 K, pRest ...K) *RadixNode[K, V] {
+	path := append([]K{p0}, pRest...)
+	curr := n
+	for len(path) != 0 {
+		e, ok := curr.children[path[0]]
+		if !ok {
+			child := &RadixNode[K, V]{order: curr.order}
+			if curr.children == nil {
+				curr.children = make(map[K]*radixEdge[K, V])
+			}
+			curr.children[path[0]] = &radixEdge[K, V]{label: path, node: child, seq: curr.order.next()}
+			curr = child
+			break
+		}
+		m := commonPrefix(e.label, path)
+		if m < len(e.label) {
+			e.split(m)
+		}
+		curr, path = e.node, path[m:]
+	}
+	curr.valued, curr.value = true, v
+	return curr
+}
+
+// Remove removes the subtree at path, merging
+// the parent into its incoming edge if it is left
+// with a single child and no value.
+func (n *RadixNode[K, V]) Remove(path ...K) (*RadixNode[K, V], bool) {
+	if len(path) == 0 {
+		return nil, false
+	}
+
+	var parentEdge *radixEdge[K, V] // edge into parent, nil if parent is n
+	parent := n
+	for {
+		e, ok := parent.children[path[0]]
+		if !ok {
+			return nil, false
+		}
+		m := commonPrefix(e.label, path)
+		if m == len(path) {
+			// Found: path ends at or inside e. Like Node.Remove,
+			// only the subtree is removed, and the keys on e
+			// leading to it are kept.
+			removed := e.node
+			if m < len(e.label) {
+				removed = &RadixNode[K, V]{order: e.node.order, children: map[K]*radixEdge[K, V]{
+					e.label[m]: {label: e.label[m:], node: e.node},
+				}}
+			}
+			if m > 1 {
+				e.label, e.node = e.label[:m-1:m-1], &RadixNode[K, V]{order: parent.order}
+				return removed, true
+			}
+			delete(parent.children, path[0])
+			if parentEdge != nil {
+				parentEdge.merge()
+			}
+			return removed, true
+		}
+		if m < len(e.label) {
+			return nil, false
+		}
+		parentEdge, parent, path = e, e.node, path[m:]
+	}
+}
+
+// split splits e after its first m keys, and returns the new middle node.
+// The rest of e keeps e's insertion sequence, since it was inserted first.
+func (e *radixEdge[K, V]) split(m int) *RadixNode[K, V] {
+	mid := &RadixNode[K, V]{order: e.node.order, children: map[K]*radixEdge[K, V]{
+		e.label[m]: {label: e.label[m:], node: e.node, seq: e.seq},
+	}}
+	e.label, e.node = e.label[:m:m], mid
+	return mid
+}
+
+// merge merges e.node into e if e.node has no value and a single child
+func (e *radixEdge[K, V]) merge() {
+	if e.node.valued || len(e.node.children) != 1 {
+		return
+	}
+	for _, child := range e.node.children {
+		e.label = append(slices.Clip(e.label), child.label...)
+		e.node = child.node
+	}
+}
+
+func commonPrefix[K comparable](a, b []K) int {
+	l := min(len(a), len(b))
+	for i := range l {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return l
+}
@@ -0,0 +1,359 @@
+package soytrie_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func TestRadixInsertAndGet(t *testing.T) {
+	root := soytrie.NewRadix[int, string]()
+
+	_ = root.Insert("0,1,2", 0, 1, 2)
+	_ = root.Insert("1,2", 1, 2)
+	_ = root.Insert("1,3", 1, 3)
+	node12345 := root.Insert("1,2,3,4,5", 1, 2, 3, 4, 5)
+
+	// 0,1,2 | 1 -> 2 -> 3,4,5 | 1 -> 3
+	if c := root.NodeCount(); c != 5 {
+		t.Fatalf("unexpected node count %d", c)
+	}
+
+	if _, ok := root.Get(1); ok {
+		t.Fatal("unexpected ok for non-valued path")
+	}
+	if v, ok := root.Get(1, 2); !ok || v != "1,2" {
+		t.Fatalf("unexpected value '%s'", v)
+	}
+	if v, ok := root.Get(1, 2, 3, 4, 5); !ok || v != "1,2,3,4,5" {
+		t.Fatalf("unexpected value '%s'", v)
+	}
+
+	// Getting inside a compressed edge does not split it
+	if _, ok := root.Get(0, 1); ok {
+		t.Fatal("unexpected ok for path inside an edge")
+	}
+	if c := root.NodeCount(); c != 5 {
+		t.Fatalf("unexpected node count %d", c)
+	}
+	if !root.Search(soytrie.ModeExact, 0, 1, 2) {
+		t.Fatal("unexpected missing 0,1,2")
+	}
+
+	// Overwrite value, but not the node
+	if overwritten := root.Insert("new", 1, 2, 3, 4, 5); overwritten != node12345 {
+		t.Fatal("unexpected pointer value")
+	}
+	if v, _ := node12345.Value(); v != "new" {
+		t.Fatalf("unexpected value '%s'", v)
+	}
+
+	if _, ok := root.Get(1, 2, 4); ok {
+		t.Fatal("unexpected ok for missing path")
+	}
+	if _, ok := root.Get(1, 2, 3, 4, 6); ok {
+		t.Fatal("unexpected ok for missing path")
+	}
+}
+
+func TestRadixCompression(t *testing.T) {
+	root := soytrie.NewRadix[string, string]()
+	_ = root.Insert("binary release foo for amd64", "/release", "/amd64", "/bin", "/foo")
+	if c := root.NodeCount(); c != 1 {
+		t.Fatalf("unexpected node count %d", c)
+	}
+	_ = root.Insert("binary release foo for aarch64", "/release", "/aarch64", "/bin", "/foo")
+	if c := root.NodeCount(); c != 3 {
+		t.Fatalf("unexpected node count %d", c)
+	}
+
+	// Removing the aarch64 branch merges /release back into one edge
+	if _, ok := root.Remove("/release", "/aarch64"); !ok {
+		t.Fatal("unexpected false")
+	}
+	if c := root.NodeCount(); c != 1 {
+		t.Fatalf("unexpected node count %d", c)
+	}
+	if !root.Search(soytrie.ModeExact, "/release", "/amd64", "/bin", "/foo") {
+		t.Fatal("unexpected missing value after merge")
+	}
+}
+
+func TestRadixRemove(t *testing.T) {
+	t.Run("remove", func(t *testing.T) {
+		root := soytrie.NewRadix[int, string]()
+		_ = root.Insert("1,2,3", 1, 2, 3)
+		_ = root.Insert("1,2,2", 1, 2, 2)
+		_ = root.Insert("1,2,7", 1, 2, 7)
+		_ = root.Insert("1,3,7", 1, 3, 7)
+		_ = root.Insert("1,3,8", 1, 3, 8)
+		_ = root.Insert("1,10,20", 1, 10, 20)
+		_ = root.Insert("0,2,3", 0, 2, 3)
+
+		if _, ok := root.Remove(1, 2); !ok {
+			t.Fatal("unexpected false")
+		}
+		if root.Search(soytrie.ModePrefix, 1, 2) {
+			t.Fatal("unexpected prefix 1,2")
+		}
+		if _, ok := root.Remove(0); !ok {
+			t.Fatal("unexpected false")
+		}
+		if root.Search(soytrie.ModePrefix, 0) {
+			t.Fatal("unexpected prefix 0")
+		}
+
+		entries, ok := root.Predict(soytrie.ModeExact, 1, 3)
+		if !ok {
+			t.Fatal("unexpected false")
+		}
+		if l := len(entries); l != 2 {
+			t.Fatalf("unexpected length %d", l)
+		}
+
+		removed, ok := root.Remove(1, 10)
+		if !ok {
+			t.Fatal("unexpected false")
+		}
+		if !removed.Search(soytrie.ModeExact, 20) {
+			t.Fatal("unexpected removed subtree")
+		}
+		if _, ok := root.Remove(1, 10); ok {
+			t.Fatal("unexpected true")
+		}
+	})
+
+	t.Run("remove and predict", func(t *testing.T) {
+		root := soytrie.NewRadix[int, string]()
+		_ = root.Insert("1,2,3", 1, 2, 3)
+		_ = root.Insert("1,2,2", 1, 2, 2)
+		_ = root.Insert("1,2,7", 1, 2, 7)
+		_ = root.Insert("1,3,7", 1, 3, 7)
+		_ = root.Insert("1,3,8", 1, 3, 8)
+		_ = root.Insert("1,10,20", 1, 10, 20)
+		_ = root.Insert("0,2,10,20,30", 0, 2, 10, 20, 30)
+		_ = root.Insert("0,2,100,200,300", 0, 2, 100, 200, 300)
+		_ = root.Insert("0,2,10,15", 0, 2, 10, 15)
+		_ = root.Insert("0,2,10,15,16", 0, 2, 10, 15, 16)
+		_ = root.Insert("0,2,10,15,17", 0, 2, 10, 15, 17)
+
+		assertLen := func(t *testing.T, expected int, path ...int) {
+			t.Helper()
+			entries, ok := root.Predict(soytrie.ModePrefix, path...)
+			if !ok {
+				t.Fatal("unexpected false")
+			}
+			if l := len(entries); l != expected {
+				t.Fatalf("unexpected Predict length %d, expecting %d", l, expected)
+			}
+		}
+
+		assertLen(t, 11, 0)
+		root.Remove(0, 2, 100, 200)
+		assertLen(t, 9, 0)
+		root.Remove(0, 2, 10, 15)
+		assertLen(t, 6, 0)
+		assertLen(t, 17)
+	})
+}
+
+func TestRadixSearch(t *testing.T) {
+	root := soytrie.NewRadix[string, string]()
+	_ = root.Insert("source code", "/src")
+	_ = root.Insert("test data", "/src", "/testdata")
+	_ = root.Insert("test case for race condition #7", "/src", "/testdata", "/race", "/7")
+	_ = root.Insert("main go program", "/src", "/cmd", "/main.go")
+	_ = root.Insert("binary releases", "/release")
+	_ = root.Insert("binary release foo for amd64", "/release", "/amd64", "/bin", "/foo")
+	_ = root.Insert("binary release foo for aarch64", "/release", "/aarch64", "/bin", "/foo")
+
+	type testCase struct {
+		path     []string
+		mode     soytrie.Mode
+		expected bool
+	}
+
+	tests := []testCase{
+		{path: []string{"/src"}, mode: soytrie.ModeExact, expected: true},
+		{path: []string{"/src"}, mode: soytrie.ModePrefix, expected: true},
+		{path: []string{"/src", "/testdata", "/race"}, mode: soytrie.ModeExact, expected: false},
+		{path: []string{"/src", "/testdata", "/race"}, mode: soytrie.ModePrefix, expected: true},
+		{path: []string{"/src", "/testdata", "/race", "/7"}, mode: soytrie.ModeExact, expected: true},
+		{path: []string{"/src", "/testdata", "/race", "/no_such"}, mode: soytrie.ModePrefix, expected: false},
+		{path: []string{"/release"}, mode: soytrie.ModePrefix, expected: true},
+		{path: []string{"/release", "/badpath"}, mode: soytrie.ModeExact, expected: false},
+		{path: []string{"/release", "/badpath"}, mode: soytrie.ModePrefix, expected: false},
+		{path: []string{"/release", "/amd64", "/bin"}, mode: soytrie.ModeExact, expected: false},
+		{path: []string{"/release", "/amd64", "/bin"}, mode: soytrie.ModePrefix, expected: true},
+		{path: []string{"/release", "/amd64", "/bin", "/foo"}, mode: soytrie.ModeExact, expected: true},
+		{path: []string{"/release", "/amd64", "/bin", "/foo"}, mode: soytrie.ModePrefix, expected: true},
+		{path: []string{"/release", "/amd64", "/bin", "/foo", "/bar"}, mode: soytrie.ModePrefix, expected: false},
+		{path: []string{"/release", "/badarch", "/bin", "/foo"}, mode: soytrie.ModePrefix, expected: false},
+		{path: []string{"/release", "/amd64", "/foo"}, mode: soytrie.ModePrefix, expected: false},
+	}
+
+	for i := range tests {
+		tc := &tests[i]
+		actual := root.Search(tc.mode, tc.path...)
+		if actual != tc.expected {
+			t.Fatalf("unexpected value %v for tests[%d] with path=%v,mode=%d,expected=%v", actual, i, tc.path, tc.mode, tc.expected)
+		}
+	}
+
+	// Lookups inside a compressed edge leave it compressed
+	count := root.NodeCount()
+	_, _ = root.Get("/release", "/amd64")
+	_ = root.Search(soytrie.ModePrefix, "/release", "/amd64", "/bin")
+	_, _ = root.Predict(soytrie.ModePrefix, "/release", "/aarch64")
+	if c := root.NodeCount(); c != count {
+		t.Fatalf("unexpected node count %d, expected %d", c, count)
+	}
+}
+
+func TestRadixPredict(t *testing.T) {
+	root := soytrie.NewRadix[int, string]()
+	_ = root.Insert("1", 1)
+	_ = root.Insert("1,2", 1, 2)
+	_ = root.Insert("1,2,3", 1, 2, 3)
+	_ = root.Insert("2", 2)
+	_ = root.Insert("2,3", 2, 3)
+	_ = root.Insert("10,20,30,40,50", 10, 20, 30, 40, 50)
+
+	type testCase struct {
+		path        []int
+		mode        soytrie.Mode
+		expectedOk  bool
+		expectedLen int
+	}
+
+	tests := []testCase{
+		{path: []int{1}, mode: soytrie.ModePrefix, expectedOk: true, expectedLen: 3},
+		{path: []int{1}, mode: soytrie.ModeExact, expectedOk: true, expectedLen: 3},
+		{path: []int{1, 2}, mode: soytrie.ModeExact, expectedOk: true, expectedLen: 2},
+		{path: []int{2, 3}, mode: soytrie.ModePrefix, expectedOk: true, expectedLen: 1},
+		{path: []int{2, 3, 4}, mode: soytrie.ModeExact, expectedOk: false, expectedLen: 0},
+		{path: []int{2, 5}, mode: soytrie.ModePrefix, expectedOk: false, expectedLen: 0},
+		{path: []int{10, 20}, mode: soytrie.ModeExact, expectedOk: true, expectedLen: 1},
+		{path: []int{10, 20}, mode: soytrie.ModePrefix, expectedOk: true, expectedLen: 4},
+		{path: []int{}, mode: soytrie.ModePrefix, expectedOk: true, expectedLen: 11},
+	}
+
+	for i := range tests {
+		tc := &tests[i]
+		actual, ok := root.Predict(tc.mode, tc.path...)
+		if ok != tc.expectedOk {
+			t.Fatalf("[case %d] unexpected ok, expecting=%v, actual=%v", i, tc.expectedOk, ok)
+		}
+		if len(actual) != tc.expectedLen {
+			t.Fatalf("[case %d] unexpected value %d, expecting %d", i, len(actual), tc.expectedLen)
+		}
+	}
+
+	entries, _ := root.Predict(soytrie.ModeExact, 10)
+	if l := len(entries); l != 1 || len(entries[0].Path) != 5 || entries[0].Value != "10,20,30,40,50" {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+}
+
+func TestRadixUnique(t *testing.T) {
+	root := soytrie.NewRadix[int, string]()
+	_ = root.Insert("1,2,3", 1, 2, 3)
+	_ = root.Insert("1,2,2", 1, 2, 2)
+	_ = root.Insert("1,2,7", 1, 2, 7)
+	_ = root.Insert("1,3,7", 1, 3, 7)
+	_ = root.Insert("1,3,8", 1, 3, 8)
+	_ = root.Insert("1,10,20", 1, 10, 20)
+	_ = root.Insert("0,2,3", 0, 2, 3)
+
+	tests := map[bool][][]int{
+		true:  {{1, 2, 7}, {1, 10}, {1, 10, 20}, {0}, {0, 2}, {0, 2, 3}},
+		false: {{1}, {1, 2}, {1, 2, 7, 8}, {0, 2, 1, 3}},
+	}
+	for expected, paths := range tests {
+		for _, path := range paths {
+			if actual := root.Unique(path...); actual != expected {
+				t.Fatalf("unexpected value %v, expecting %v with path=%v", actual, expected, path)
+			}
+		}
+	}
+}
+
+func TestRadixMatchesNode(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	randPath := func() []int {
+		path := make([]int, 1+rng.IntN(5))
+		for i := range path {
+			path[i] = rng.IntN(3)
+		}
+		return path
+	}
+
+	node := soytrie.New[int, int]()
+	radix := soytrie.NewRadix[int, int]()
+	for i := range 2000 {
+		path := randPath()
+		switch rng.IntN(3) {
+		case 0, 1:
+			_ = node.Insert(i, path[0], path[1:]...)
+			_ = radix.Insert(i, path[0], path[1:]...)
+		case 2:
+			_, okNode := node.Remove(path...)
+			_, okRadix := radix.Remove(path...)
+			if okNode != okRadix {
+				t.Fatalf("unexpected Remove result %v for path %v", okRadix, path)
+			}
+		}
+
+		query := randPath()
+		query = query[:rng.IntN(len(query)+1)]
+		for _, mode := range []soytrie.Mode{soytrie.ModeExact, soytrie.ModePrefix} {
+			expected, okNode := node.PredictPaths(mode, 0, query...)
+			actual, okRadix := radix.Predict(mode, query...)
+			byPath := func(a, b soytrie.Entry[int, int]) int { return slices.Compare(a.Path, b.Path) }
+			slices.SortFunc(expected, byPath)
+			slices.SortFunc(actual, byPath)
+			if okNode != okRadix || !slices.EqualFunc(expected, actual, func(a, b soytrie.Entry[int, int]) bool {
+				return slices.Equal(a.Path, b.Path) && a.Value == b.Value && a.Valued == b.Valued
+			}) {
+				t.Fatalf("[step %d] unexpected Predict result %v (%v) for path %v, expecting %v (%v)", i, actual, okRadix, query, expected, okNode)
+			}
+			if node.Search(mode, query...) != radix.Search(mode, query...) {
+				t.Fatalf("[step %d] unexpected Search result for path %v", i, query)
+			}
+		}
+
+		var expected soytrie.Entry[int, int]
+		if target, ok := node.Get(query...); ok && target.Valued {
+			expected = soytrie.Entry[int, int]{Value: target.Value, Valued: true}
+		}
+		if v, ok := radix.Get(query...); v != expected.Value || ok != expected.Valued {
+			t.Fatalf("[step %d] unexpected Get result %d (%v) for path %v, expecting %+v", i, v, ok, query, expected)
+		}
+		if node.Unique(query...) != radix.Unique(query...) {
+			t.Fatalf("[step %d] unexpected Unique result for path %v", i, query)
+		}
+	}
+}
+
+func TestRadixConcurrentReads(t *testing.T) {
+	root := soytrie.NewRadix[int, int]()
+	_ = root.Insert(1, 1, 2, 3, 4, 5)
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = root.Get(1, 2, i%4+1)
+			_ = root.Search(soytrie.ModePrefix, 1, 2)
+			_, _ = root.Predict(soytrie.ModePrefix, 1)
+		}()
+	}
+	wg.Wait()
+	if c := root.NodeCount(); c != 1 {
+		t.Fatalf("unexpected node count %d", c)
+	}
+}
@@ -0,0 +1,134 @@
+package soytrie
+
+import (
+	"math/rand/v2"
+	"slices"
+)
+
+// CountPrefix returns the number of entries whose paths start with path.
+// Trie keeps these counts on every node, so this is O(depth).
+func (t *Trie[K, V]) CountPrefix(path ...K) int {
+	node, ok := t.root.Get(path...)
+	if !ok {
+		return 0
+	}
+	return node.count
+}
+
+// Rank returns the number of entries visited before path by Values,
+// in the order set with SetOrder. It returns false if there is no
+// entry at path, or if t has no Order, since map order is not stable.
+func (t *Trie[K, V]) Rank(path ...K) (int, bool) {
+	if t.root.order == nil {
+		return 0, false
+	}
+	rank, curr := 0, t.root
+	for _, k := range path {
+		next, ok := curr.GetDirect(k)
+		if !ok {
+			return 0, false
+		}
+		if curr.Valued {
+			rank++
+		}
+		for _, c := range curr.childKeys() {
+			if c == k {
+				break
+			}
+			rank += curr.Children[c].count
+		}
+		curr = next
+	}
+	if !curr.Valued {
+		return 0, false
+	}
+	return rank, true
+}
+
+// Select returns the i-th entry visited by Values, counting from 0,
+// in the order set with SetOrder. Like Rank, it returns false
+// if t has no Order.
+func (t *Trie[K, V]) Select(i int) (Entry[K, V], bool) {
+	if t.root.order == nil || i < 0 || i >= t.root.count {
+		return Entry[K, V]{}, false
+	}
+	return t.selectAny(i)
+}
+
+// RandomEntry returns an entry chosen uniformly at random using rng.
+// It does not need an Order.
+func (t *Trie[K, V]) RandomEntry(rng *rand.Rand) (Entry[K, V], bool) {
+	if t.root.count == 0 {
+		return Entry[K, V]{}, false
+	}
+	return t.selectAny(rng.IntN(t.root.count))
+}
+
+// selectAny implements Select, walking children in whatever order
+// they have. Each node's children are only listed once, so the
+// result is still the i-th entry of some consistent order.
+func (t *Trie[K, V]) selectAny(i int) (Entry[K, V], bool) {
+	path, curr := []K{}, t.root
+	for {
+		if curr.Valued {
+			if i == 0 {
+				return Entry[K, V]{Path: slices.Clip(path), Value: curr.Value, Valued: true}, true
+			}
+			i--
+		}
+		for _, k := range curr.childKeys() {
+			child := curr.Children[k]
+			if i < child.count {
+				path, curr = append(path, k), child
+				break
+			}
+			i -= child.count
+		}
+	}
+}
+
+// grew updates the counts after inserting p0+pRest, which created
+// the last created nodes on the path, and stored a new entry if valued
+func (t *Trie[K, V]) grew(p0 K, pRest []K, created int, valued bool) {
+	entries := 0
+	if valued {
+		entries = 1
+	}
+	depth := len(pRest) + 1
+	curr := t.root
+	curr.count += entries
+	curr.nodes += created
+	for d := 1; d <= depth; d++ {
+		k := p0
+		if d > 1 {
+			k = pRest[d-2]
+		}
+		curr = curr.Children[k]
+		curr.count += entries
+		if d <= depth-created {
+			curr.nodes += created
+		} else {
+			// A new node, whose descendants are the rest of the path
+			curr.nodes = depth - d
+		}
+	}
+}
+
+// shrink updates the counts of n and its descendants along path,
+// after entries and nodes were removed below them.
+// It stops at the first missing node.
+func (n *Node[K, V]) shrink(entries, nodes int, path []K) {
+	curr := n
+	for i := 0; ; i++ {
+		curr.count -= entries
+		curr.nodes -= nodes
+		if i == len(path) {
+			return
+		}
+		next, ok := curr.GetDirect(path[i])
+		if !ok {
+			return
+		}
+		curr = next
+	}
+}
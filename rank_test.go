@@ -0,0 +1,148 @@
+package soytrie_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func TestCountPrefix(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	trie := soytrie.NewTrie[int, int]()
+	root := soytrie.New[int, int]()
+
+	for i := range 2000 {
+		path := make([]int, 1+rng.IntN(4))
+		for j := range path {
+			path[j] = rng.IntN(3)
+		}
+		switch rng.IntN(4) {
+		case 0:
+			trie.Remove(path...)
+			root.Remove(path...)
+		case 1:
+			trie.Delete(path...)
+			root.Delete(path...)
+		default:
+			trie.Insert(i, path[0], path[1:]...)
+			_ = root.Insert(i, path[0], path[1:]...)
+		}
+
+		prefix := path[:rng.IntN(len(path)+1)]
+		expected := 0
+		for range root.WithPrefix(prefix...) {
+			expected++
+		}
+		if actual := trie.CountPrefix(prefix...); actual != expected {
+			t.Fatalf("[%d] unexpected CountPrefix(%v) %d, expected %d", i, prefix, actual, expected)
+		}
+		if trie.Unique(prefix...) != root.Unique(prefix...) {
+			t.Fatalf("[%d] unexpected Unique(%v)", i, prefix)
+		}
+		nodes := -1 // root is not counted
+		for range root.All() {
+			nodes++
+		}
+		if actual := trie.NodeCount(); actual != nodes {
+			t.Fatalf("[%d] unexpected NodeCount %d, expected %d", i, actual, nodes)
+		}
+	}
+	if trie.CountPrefix() != trie.Len() {
+		t.Fatalf("unexpected CountPrefix() %d, Len %d", trie.CountPrefix(), trie.Len())
+	}
+}
+
+func TestRankSelect(t *testing.T) {
+	trie := soytrie.NewTrie[string, int]()
+	trie.SetOrder(soytrie.OrderAscending[string]())
+	trie.Insert(0, "a")
+	trie.Insert(1, "a", "b")
+	trie.Insert(3, "b")
+	trie.Insert(2, "a", "c", "d")
+	trie.Insert(4, "c", "a")
+
+	i := 0
+	for path, v := range trie.Values() {
+		if v != i {
+			t.Fatalf("unexpected value %d at %v", v, path)
+		}
+		rank, ok := trie.Rank(path...)
+		if !ok || rank != i {
+			t.Fatalf("unexpected Rank(%v) %d %v, expected %d", path, rank, ok, i)
+		}
+		e, ok := trie.Select(i)
+		if !ok || e.Value != i || !slices.Equal(e.Path, path) {
+			t.Fatalf("unexpected Select(%d) %v %v", i, e, ok)
+		}
+		i++
+	}
+
+	if _, ok := trie.Rank("a", "c"); ok {
+		t.Fatalf("unexpected Rank of non-valued path")
+	}
+	if _, ok := trie.Rank("x"); ok {
+		t.Fatalf("unexpected Rank of missing path")
+	}
+	if _, ok := trie.Select(-1); ok {
+		t.Fatalf("unexpected Select(-1)")
+	}
+	if _, ok := trie.Select(trie.Len()); ok {
+		t.Fatalf("unexpected Select(Len)")
+	}
+}
+
+func TestRandomEntry(t *testing.T) {
+	rng := rand.New(rand.NewPCG(5, 6))
+	trie := soytrie.NewTrie[int, int]()
+	if _, ok := trie.RandomEntry(rng); ok {
+		t.Fatalf("unexpected RandomEntry on empty trie")
+	}
+
+	// Entries at very different depths should be equally likely
+	trie.Insert(0, 0)
+	trie.Insert(1, 1, 1, 1, 1, 1)
+	trie.Insert(2, 1, 1, 1, 1, 2)
+	trie.Insert(3, 2, 3)
+
+	counts := make([]int, 4)
+	const samples = 40000
+	for range samples {
+		e, ok := trie.RandomEntry(rng)
+		if !ok {
+			t.Fatalf("unexpected RandomEntry failure")
+		}
+		counts[e.Value]++
+	}
+	for v, count := range counts {
+		if count < samples/4*9/10 || count > samples/4*11/10 {
+			t.Fatalf("unexpected count %d for %d: %v", count, v, counts)
+		}
+	}
+}
+
+func TestRankSelectWithoutOrder(t *testing.T) {
+	trie := soytrie.NewTrie[int, int]()
+	for i := range 50 {
+		trie.Insert(i, i)
+	}
+	if _, ok := trie.Rank(1); ok {
+		t.Fatalf("unexpected Rank without Order")
+	}
+	if _, ok := trie.Select(0); ok {
+		t.Fatalf("unexpected Select without Order")
+	}
+
+	trie.SetOrder(soytrie.OrderAscending[int]())
+	for i := range 50 {
+		e, ok := trie.Select(i)
+		if !ok {
+			t.Fatalf("unexpected Select(%d) failure", i)
+		}
+		rank, ok := trie.Rank(e.Path...)
+		if !ok || rank != i || e.Value != i {
+			t.Fatalf("unexpected Rank(%v) %d %v, expected %d", e.Path, rank, ok, i)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+// Package router provides a net/http router backed by soytrie.
+//
+// Patterns are split into path segments. A segment ":name" matches
+// any single segment, and a trailing segment "*name" matches the rest
+// of the path (possibly empty). Static segments take precedence over
+// parameters, which take precedence over catch-alls.
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/soyart/soytrie-go"
+)
+
+const (
+	keyParam    = ":"
+	keyWildcard = "*"
+)
+
+// Param is a matched pattern parameter
+type Param struct {
+	Key   string
+	Value string
+}
+
+// Params are the parameters matched for a request, in pattern order
+type Params []Param
+
+// Get returns the value of the parameter named key
+func (p Params) Get(key string) string {
+	for i := range p {
+		if p[i].Key == key {
+			return p[i].Value
+		}
+	}
+	return ""
+}
+
+type paramsKey struct{}
+
+// ParamsFromContext returns the parameters matched for the request
+// whose context is ctx
+func ParamsFromContext(ctx context.Context) Params {
+	p, _ := ctx.Value(paramsKey{}).(Params)
+	return p
+}
+
+// route is stored as the value of a pattern's trie node
+type route struct {
+	pattern  string
+	names    []string // parameter names, in pattern order
+	handlers map[string]http.Handler
+}
+
+// Router is an http.Handler that dispatches requests
+// by method and URL path
+type Router struct {
+	root *soytrie.Node[string, *route]
+
+	// NotFound handles requests with no matching pattern.
+	// If nil, http.NotFound is used.
+	NotFound http.Handler
+
+	// MethodNotAllowed handles requests whose path matches
+	// a pattern, but not for the request method.
+	// The Allow header is set before it is called.
+	MethodNotAllowed http.Handler
+}
+
+func New() *Router {
+	return &Router{root: soytrie.New[string, *route]()}
+}
+
+// Handle registers h for method and pattern.
+// It panics if the pattern is invalid, or conflicts
+// with an existing registration.
+func (r *Router) Handle(method, pattern string, h http.Handler) {
+	curr := r.root
+	names := []string{}
+	segments := split(pattern)
+	for i, s := range segments {
+		key := s
+		switch {
+		case strings.HasPrefix(s, keyParam):
+			key = keyParam
+			names = append(names, s[1:])
+		case strings.HasPrefix(s, keyWildcard):
+			if i != len(segments)-1 {
+				panic(fmt.Sprintf("router: catch-all %q must be the last segment in pattern %q", s, pattern))
+			}
+			key = keyWildcard
+			names = append(names, s[1:])
+		}
+		if key != s && len(s) == 1 {
+			panic(fmt.Sprintf("router: unnamed parameter in pattern %q", pattern))
+		}
+		curr, _ = curr.GetOrInsertDirect(key, soytrie.New[string, *route]())
+	}
+
+	if !curr.Valued {
+		curr.Valued, curr.Value = true, &route{
+			pattern:  pattern,
+			names:    names,
+			handlers: make(map[string]http.Handler),
+		}
+	}
+	rt := curr.Value
+	if !slices.Equal(rt.names, names) {
+		panic(fmt.Sprintf("router: pattern %q conflicts with %q", pattern, rt.pattern))
+	}
+	if _, ok := rt.handlers[method]; ok {
+		panic(fmt.Sprintf("router: duplicate registration for %s %s", method, pattern))
+	}
+	rt.handlers[method] = h
+}
+
+// HandleFunc registers f for method and pattern. See Handle.
+func (r *Router) HandleFunc(method, pattern string, f func(http.ResponseWriter, *http.Request)) {
+	r.Handle(method, pattern, http.HandlerFunc(f))
+}
+
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	m := matcher{method: req.Method, allowed: map[string]bool{}}
+	if !m.match(r.root, split(req.URL.Path)) {
+		if len(m.allowed) == 0 {
+			r.notFound(w, req)
+			return
+		}
+		allowed := make([]string, 0, len(m.allowed))
+		for method := range m.allowed {
+			allowed = append(allowed, method)
+		}
+		slices.Sort(allowed)
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		r.methodNotAllowed(w, req)
+		return
+	}
+
+	params := make(Params, len(m.values))
+	for i := range m.values {
+		params[i] = Param{Key: m.route.names[i], Value: m.values[i]}
+	}
+	ctx := context.WithValue(req.Context(), paramsKey{}, params)
+	m.route.handlers[req.Method].ServeHTTP(w, req.WithContext(ctx))
+}
+
+func (r *Router) notFound(w http.ResponseWriter, req *http.Request) {
+	if r.NotFound != nil {
+		r.NotFound.ServeHTTP(w, req)
+		return
+	}
+	http.NotFound(w, req)
+}
+
+func (r *Router) methodNotAllowed(w http.ResponseWriter, req *http.Request) {
+	if r.MethodNotAllowed != nil {
+		r.MethodNotAllowed.ServeHTTP(w, req)
+		return
+	}
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+}
+
+// matcher searches the trie for a route handling method,
+// remembering the methods of routes matching only by path
+type matcher struct {
+	method  string
+	route   *route
+	values  []string
+	allowed map[string]bool
+}
+
+func (m *matcher) match(node *soytrie.Node[string, *route], segments []string) bool {
+	if len(segments) == 0 && m.accept(node) {
+		return true
+	}
+	if len(segments) != 0 {
+		// Request segments ":" and "*" must not hit the parameter nodes
+		// as if they were static segments
+		s := segments[0]
+		if s != keyParam && s != keyWildcard {
+			if next, ok := node.GetDirect(s); ok && m.match(next, segments[1:]) {
+				return true
+			}
+		}
+		if next, ok := node.GetDirect(keyParam); ok {
+			m.values = append(m.values, segments[0])
+			if m.match(next, segments[1:]) {
+				return true
+			}
+			m.values = m.values[:len(m.values)-1]
+		}
+	}
+	if next, ok := node.GetDirect(keyWildcard); ok {
+		m.values = append(m.values, strings.Join(segments, "/"))
+		if m.accept(next) {
+			return true
+		}
+		m.values = m.values[:len(m.values)-1]
+	}
+	return false
+}
+
+// accept reports whether node has a handler for m.method
+func (m *matcher) accept(node *soytrie.Node[string, *route]) bool {
+	if !node.Valued {
+		return false
+	}
+	if _, ok := node.Value.handlers[m.method]; !ok {
+		for method := range node.Value.handlers {
+			m.allowed[method] = true
+		}
+		return false
+	}
+	m.route = node.Value
+	return true
+}
+
+// split splits path into its non-empty segments
+func split(path string) []string {
+	segments := strings.Split(path, "/")
+	return slices.DeleteFunc(segments, func(s string) bool {
+		return s == ""
+	})
+}
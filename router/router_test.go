@@ -0,0 +1,118 @@
+package router_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/soyart/soytrie-go/router"
+)
+
+func TestRouter(t *testing.T) {
+	r := router.New()
+	handler := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			params := router.ParamsFromContext(req.Context())
+			fmt.Fprintf(w, "%s %v", name, params)
+		}
+	}
+
+	r.Handle(http.MethodGet, "/", handler("root"))
+	r.Handle(http.MethodGet, "/src", handler("src"))
+	r.Handle(http.MethodGet, "/src/testdata", handler("testdata"))
+	r.Handle(http.MethodGet, "/users/new", handler("new user form"))
+	r.Handle(http.MethodGet, "/users/:id", handler("get user"))
+	r.Handle(http.MethodDelete, "/users/:id", handler("delete user"))
+	r.Handle(http.MethodPost, "/users/:id", handler("update user"))
+	r.Handle(http.MethodGet, "/users/:id/posts/:post", handler("get post"))
+	r.Handle(http.MethodGet, "/static/*filepath", handler("static"))
+	r.Handle(http.MethodGet, "/release/:arch/bin/:name", handler("binary"))
+
+	type testCase struct {
+		method         string
+		path           string
+		expectedStatus int
+		expectedBody   string
+		expectedAllow  string
+	}
+
+	tests := []testCase{
+		{method: http.MethodGet, path: "/", expectedStatus: 200, expectedBody: "root []"},
+		{method: http.MethodGet, path: "/src/", expectedStatus: 200, expectedBody: "src []"},
+		{method: http.MethodGet, path: "/src/testdata", expectedStatus: 200, expectedBody: "testdata []"},
+		{method: http.MethodGet, path: "/users/new", expectedStatus: 200, expectedBody: "new user form []"},
+		{method: http.MethodGet, path: "/users/7", expectedStatus: 200, expectedBody: "get user [{id 7}]"},
+		{method: http.MethodPost, path: "/users/new", expectedStatus: 200, expectedBody: "update user [{id new}]"},
+		{method: http.MethodDelete, path: "/users/7", expectedStatus: 200, expectedBody: "delete user [{id 7}]"},
+		{method: http.MethodGet, path: "/users/7/posts/42", expectedStatus: 200, expectedBody: "get post [{id 7} {post 42}]"},
+		{method: http.MethodGet, path: "/static/css/main.css", expectedStatus: 200, expectedBody: "static [{filepath css/main.css}]"},
+		{method: http.MethodGet, path: "/static", expectedStatus: 200, expectedBody: "static [{filepath }]"},
+		{method: http.MethodGet, path: "/release/amd64/bin/foo", expectedStatus: 200, expectedBody: "binary [{arch amd64} {name foo}]"},
+		{method: http.MethodGet, path: "/users/:", expectedStatus: 200, expectedBody: "get user [{id :}]"},
+		{method: http.MethodGet, path: "/users", expectedStatus: 404},
+		{method: http.MethodGet, path: "/users/7/posts", expectedStatus: 404},
+		{method: http.MethodGet, path: "/release/amd64/lib/foo", expectedStatus: 404},
+		{method: http.MethodGet, path: "/no/such/path", expectedStatus: 404},
+		{method: http.MethodPut, path: "/users/7", expectedStatus: 405, expectedAllow: "DELETE, GET, POST"},
+		{method: http.MethodPost, path: "/src", expectedStatus: 405, expectedAllow: "GET"},
+		{method: http.MethodPost, path: "/static/main.css", expectedStatus: 405, expectedAllow: "GET"},
+	}
+
+	for i := range tests {
+		tc := &tests[i]
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, nil))
+		if rec.Code != tc.expectedStatus {
+			t.Fatalf("[case %d] unexpected status %d for %s %s, expecting %d", i, rec.Code, tc.method, tc.path, tc.expectedStatus)
+		}
+		if tc.expectedStatus == 200 && rec.Body.String() != tc.expectedBody {
+			t.Fatalf("[case %d] unexpected body '%s', expecting '%s'", i, rec.Body.String(), tc.expectedBody)
+		}
+		if allow := rec.Header().Get("Allow"); allow != tc.expectedAllow {
+			t.Fatalf("[case %d] unexpected Allow header '%s', expecting '%s'", i, allow, tc.expectedAllow)
+		}
+	}
+
+	t.Run("custom handlers", func(t *testing.T) {
+		r := router.New()
+		r.HandleFunc(http.MethodGet, "/a", func(w http.ResponseWriter, _ *http.Request) {})
+		r.NotFound = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		})
+		r.MethodNotAllowed = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+		})
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/b", nil))
+		if rec.Code != http.StatusTeapot {
+			t.Fatalf("unexpected status %d", rec.Code)
+		}
+		rec = httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/a", nil))
+		if rec.Code != http.StatusConflict {
+			t.Fatalf("unexpected status %d", rec.Code)
+		}
+	})
+
+	t.Run("invalid patterns", func(t *testing.T) {
+		patterns := []string{
+			"/users/:",
+			"/static/*",
+			"/static/*filepath/more",
+			"/users/:name", // conflicts with /users/:id
+			"/src",         // duplicate GET /src
+		}
+		for _, pattern := range patterns {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Fatalf("unexpected nil panic for pattern %s", pattern)
+					}
+				}()
+				r.Handle(http.MethodGet, pattern, handler("bad"))
+			}()
+		}
+	})
+}
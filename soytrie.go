@@ -16,6 +16,15 @@ type Node[K comparable, V any] struct {
 	Value    V
 	Valued   bool
 	Children map[K]*Node[K, V]
+
+	order *Order[K]
+	seq   uint64 // insertion sequence, used by OrderInsertion
+
+	// count and nodes are the numbers of valued nodes and of descendants
+	// in the subtree. They are only maintained by Trie, and are stale
+	// on nodes modified through Node methods, so Node must not read them.
+	count int
+	nodes int
 }
 
 func New[K comparable, V any]() *Node[K, V] {
@@ -96,7 +105,7 @@ func Collect[K comparable, V any](
 	if testFn == nil || testFn(node) {
 		*collector = append(*collector, node)
 	}
-	for _, child := range node.Children {
+	for _, child := range node.children() {
 		Collect(testFn, child, collector)
 	}
 }
@@ -148,6 +157,47 @@ func (n *Node[K, V]) Remove(path ...K) (*Node[K, V], bool) {
 	return last.RemoveDirect(path[l-1])
 }
 
+// Delete clears the value at path, keeping the node's descendants.
+// Ancestors (and the node itself) that are left with no value
+// and no children are pruned. The receiver is never pruned.
+func (n *Node[K, V]) Delete(path ...K) (V, bool) {
+	old, _, ok := n.deletePrune(path)
+	return old, ok
+}
+
+// deletePrune implements Delete, and also returns
+// the number of nodes pruned
+func (n *Node[K, V]) deletePrune(path []K) (V, int, bool) {
+	var zero V
+	nodes := make([]*Node[K, V], 0, len(path)+1)
+	nodes = append(nodes, n)
+	curr := n
+	for i := range path {
+		next, ok := curr.GetDirect(path[i])
+		if !ok {
+			return zero, 0, false
+		}
+		nodes = append(nodes, next)
+		curr = next
+	}
+	if !curr.Valued {
+		return zero, 0, false
+	}
+	old := curr.Value
+	curr.Valued, curr.Value = false, zero
+
+	pruned := 0
+	for i := len(path); i > 0; i-- {
+		node := nodes[i]
+		if node.Valued || len(node.Children) != 0 {
+			break
+		}
+		nodes[i-1].RemoveDirect(path[i-1])
+		pruned++
+	}
+	return old, pruned, true
+}
+
 func (n *Node[K, V]) GetOrInsertDirectValue(k K, v V) (*Node[K, V], bool) {
 	old, ok := n.GetDirect(k)
 	if ok {
@@ -158,6 +208,7 @@ func (n *Node[K, V]) GetOrInsertDirectValue(k K, v V) (*Node[K, V], bool) {
 	if n.Children == nil {
 		n.Children = make(map[K]*Node[K, V])
 	}
+	n.adopt(child)
 
 	n.Children[k] = child
 	return child, false
@@ -171,6 +222,7 @@ func (n *Node[K, V]) GetOrInsertDirect(k K, node *Node[K, V]) (*Node[K, V], bool
 	if n.Children == nil {
 		n.Children = make(map[K]*Node[K, V])
 	}
+	n.adopt(node)
 	n.Children[k] = node
 	return node, false
 }
@@ -238,6 +238,76 @@ func TestRemove(t *testing.T) {
 	})
 }
 
+func TestDelete(t *testing.T) {
+	root := soytrie.New[int, string]()
+	_ = root.Insert("1,2", 1, 2)
+	_ = root.Insert("1,2,3,4", 1, 2, 3, 4)
+	_ = root.Insert("1,5,6,7", 1, 5, 6, 7)
+	_ = root.Insert("0", 0)
+
+	old, ok := root.Delete(1, 2)
+	if !ok {
+		t.Fatal("unexpected false")
+	}
+	if old != "1,2" {
+		t.Fatalf("unexpected old value '%s'", old)
+	}
+	node12, ok := root.Get(1, 2)
+	if !ok {
+		t.Fatal("unexpected pruning of node with children")
+	}
+	if node12.Valued || node12.Value != "" {
+		t.Fatalf("unexpected value '%s' after delete", node12.Value)
+	}
+	if !root.Search(soytrie.ModeExact, 1, 2, 3, 4) {
+		t.Fatal("unexpected missing descendant")
+	}
+
+	if _, ok := root.Delete(1, 2); ok {
+		t.Fatal("unexpected true for deleting non-valued node")
+	}
+	if _, ok := root.Delete(1, 2, 3); ok {
+		t.Fatal("unexpected true for deleting non-valued node")
+	}
+	if _, ok := root.Delete(9, 9); ok {
+		t.Fatal("unexpected true for deleting missing path")
+	}
+
+	// Deleting 1,2,3,4 should prune 1,2,3 and 1,2, but not 1
+	if _, ok := root.Delete(1, 2, 3, 4); !ok {
+		t.Fatal("unexpected false")
+	}
+	if root.Search(soytrie.ModePrefix, 1, 2) {
+		t.Fatal("unexpected dead prefix 1,2")
+	}
+	node1, ok := root.Get(1)
+	if !ok {
+		t.Fatal("unexpected pruning of 1")
+	}
+	if l := len(node1.Children); l != 1 {
+		t.Fatalf("unexpected number of children %d", l)
+	}
+
+	// Deleting 1,5,6,7 should prune everything up to root
+	if _, ok := root.Delete(1, 5, 6, 7); !ok {
+		t.Fatal("unexpected false")
+	}
+	if root.Search(soytrie.ModePrefix, 1) {
+		t.Fatal("unexpected dead prefix 1")
+	}
+	if l := len(root.Children); l != 1 {
+		t.Fatalf("unexpected number of root children %d", l)
+	}
+
+	collector, ok := root.Predict(soytrie.ModePrefix)
+	if !ok {
+		t.Fatal("unexpected false")
+	}
+	if l := len(collector); l != 2 { // root and 0
+		t.Fatalf("unexpected Predict length %d", l)
+	}
+}
+
 func TestSearch(t *testing.T) {
 	dirRoot := soytrie.NewWithValue[string]("/")
 	_ = dirRoot.Insert(
@@ -0,0 +1,180 @@
+package soytrie
+
+import "unicode/utf8"
+
+// StringTrie is a trie keyed by strings, which are split
+// into runes (or bytes) and stored in a Node[rune, V].
+//
+// Lookups walk the string in place and do not allocate.
+// In rune mode, invalid UTF-8 bytes are stored as utf8.RuneError.
+type StringTrie[V any] struct {
+	root  *Node[rune, V]
+	bytes bool
+}
+
+// StringEntry is a string key paired with its value
+type StringEntry[V any] struct {
+	Key    string
+	Value  V
+	Valued bool
+}
+
+// NewStringTrie returns a StringTrie that splits strings into runes
+func NewStringTrie[V any]() *StringTrie[V] {
+	return &StringTrie[V]{root: New[rune, V]()}
+}
+
+// NewStringTrieBytes returns a StringTrie that splits strings into bytes
+func NewStringTrieBytes[V any]() *StringTrie[V] {
+	return &StringTrie[V]{root: New[rune, V](), bytes: true}
+}
+
+// Root returns the underlying root node
+func (t *StringTrie[V]) Root() *Node[rune, V] {
+	return t.root
+}
+
+func (t *StringTrie[V]) Insert(v V, s string) {
+	if s == "" {
+		t.root.Valued, t.root.Value = true, v
+		return
+	}
+	units := t.units(s)
+	_ = t.root.Insert(v, units[0], units[1:]...)
+}
+
+// Get returns the value at s, if any
+func (t *StringTrie[V]) Get(s string) (V, bool) {
+	node, ok := t.get(s)
+	if !ok || !node.Valued {
+		var zero V
+		return zero, false
+	}
+	return node.Value, true
+}
+
+func (t *StringTrie[V]) Search(mode Mode, s string) bool {
+	node, ok := t.get(s)
+	if !ok {
+		return false
+	}
+	if mode == ModePrefix {
+		return true
+	}
+	return node.Valued
+}
+
+// Predict returns entries under s with their full keys.
+// See Node.Predict for the meaning of mode.
+func (t *StringTrie[V]) Predict(mode Mode, s string) ([]StringEntry[V], bool) {
+	target, ok := t.get(s)
+	if !ok {
+		return nil, false
+	}
+
+	var testFn func(*Node[rune, V]) bool
+	if mode == ModeExact {
+		testFn = isValued[rune, V]
+	}
+
+	entries := []StringEntry[V]{}
+	walkSeq(testFn, target, nil, func(suffix []rune, node *Node[rune, V]) bool {
+		entries = append(entries, StringEntry[V]{
+			Key:    s + t.toString(suffix),
+			Value:  node.Value,
+			Valued: node.Valued,
+		})
+		return true
+	})
+	return entries, true
+}
+
+// Unique returns whether s is a unique key
+// or a prefix to a valued node.
+func (t *StringTrie[V]) Unique(s string) bool {
+	node, ok := t.get(s)
+	if !ok {
+		return false
+	}
+	return countValued(node, 2) == 1
+}
+
+// countValued counts valued nodes under node,
+// stopping early once limit is reached
+func countValued[K comparable, V any](node *Node[K, V], limit int) int {
+	count := 0
+	if node.Valued {
+		count++
+	}
+	for _, child := range node.Children {
+		if count >= limit {
+			break
+		}
+		count += countValued(child, limit-count)
+	}
+	return count
+}
+
+// Remove removes the subtree at s
+func (t *StringTrie[V]) Remove(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	size := 1
+	last := rune(s[len(s)-1])
+	if !t.bytes {
+		last, size = utf8.DecodeLastRuneInString(s)
+	}
+	parent, ok := t.get(s[:len(s)-size])
+	if !ok {
+		return false
+	}
+	_, ok = parent.RemoveDirect(last)
+	return ok
+}
+
+func (t *StringTrie[V]) get(s string) (*Node[rune, V], bool) {
+	curr := t.root
+	if t.bytes {
+		for i := 0; i < len(s); i++ {
+			next, ok := curr.GetDirect(rune(s[i]))
+			if !ok {
+				return nil, false
+			}
+			curr = next
+		}
+		return curr, true
+	}
+	for _, r := range s {
+		next, ok := curr.GetDirect(r)
+		if !ok {
+			return nil, false
+		}
+		curr = next
+	}
+	return curr, true
+}
+
+// units returns the runes (or bytes) of s
+func (t *StringTrie[V]) units(s string) []rune {
+	if !t.bytes {
+		return []rune(s)
+	}
+	units := make([]rune, len(s))
+	for i := 0; i < len(s); i++ {
+		units[i] = rune(s[i])
+	}
+	return units
+}
+
+func (t *StringTrie[V]) toString(path []rune) string {
+	if !t.bytes {
+		return string(path)
+	}
+	b := make([]byte, len(path))
+	for i := range path {
+		b[i] = byte(path[i])
+	}
+	return string(b)
+}
@@ -0,0 +1,95 @@
+package soytrie_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func TestStringTrie(t *testing.T) {
+	words := []string{"car", "cart", "care", "cat", "dog", "ดอก", "ดอกไม้"}
+
+	for name, trie := range map[string]*soytrie.StringTrie[int]{
+		"runes": soytrie.NewStringTrie[int](),
+		"bytes": soytrie.NewStringTrieBytes[int](),
+	} {
+		t.Run(name, func(t *testing.T) {
+			for i, w := range words {
+				trie.Insert(i, w)
+			}
+
+			for i, w := range words {
+				v, ok := trie.Get(w)
+				if !ok || v != i {
+					t.Fatalf("unexpected Get result %d %v for %s", v, ok, w)
+				}
+				if !trie.Search(soytrie.ModeExact, w) {
+					t.Fatalf("unexpected false for %s", w)
+				}
+			}
+			if _, ok := trie.Get("ca"); ok {
+				t.Fatal("unexpected ok for non-valued key")
+			}
+			if !trie.Search(soytrie.ModePrefix, "ca") || trie.Search(soytrie.ModeExact, "ca") {
+				t.Fatal("unexpected Search result for ca")
+			}
+
+			entries, ok := trie.Predict(soytrie.ModeExact, "car")
+			if !ok {
+				t.Fatal("unexpected false")
+			}
+			keys := []string{}
+			for _, e := range entries {
+				keys = append(keys, e.Key)
+			}
+			slices.Sort(keys)
+			if expected := []string{"car", "care", "cart"}; !slices.Equal(expected, keys) {
+				t.Fatalf("unexpected keys %v, expecting %v", keys, expected)
+			}
+
+			entries, ok = trie.Predict(soytrie.ModeExact, "ดอ")
+			if !ok || len(entries) != 2 {
+				t.Fatalf("unexpected Predict result %+v", entries)
+			}
+			for _, e := range entries {
+				if e.Key != "ดอก" && e.Key != "ดอกไม้" {
+					t.Fatalf("unexpected key %s", e.Key)
+				}
+			}
+
+			if trie.Unique("ca") || !trie.Unique("do") || !trie.Unique("dog") || trie.Unique("x") {
+				t.Fatal("unexpected Unique result")
+			}
+
+			if !trie.Remove("ดอกไม้") {
+				t.Fatal("unexpected false")
+			}
+			if trie.Search(soytrie.ModeExact, "ดอกไม้") || !trie.Search(soytrie.ModeExact, "ดอก") {
+				t.Fatal("unexpected Remove result")
+			}
+			if !trie.Remove("car") {
+				t.Fatal("unexpected false")
+			}
+			if trie.Search(soytrie.ModePrefix, "care") || !trie.Search(soytrie.ModeExact, "cat") {
+				t.Fatal("unexpected Remove result")
+			}
+			if trie.Remove("car") || trie.Remove("") {
+				t.Fatal("unexpected true")
+			}
+		})
+	}
+
+	t.Run("lookups do not allocate", func(t *testing.T) {
+		trie := soytrie.NewStringTrie[int]()
+		trie.Insert(1, "ดอกไม้")
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _ = trie.Get("ดอกไม้")
+			_ = trie.Search(soytrie.ModePrefix, "ดอก")
+			_ = trie.Unique("ดอ")
+		})
+		if allocs != 0 {
+			t.Fatalf("unexpected allocations %v", allocs)
+		}
+	})
+}
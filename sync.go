@@ -0,0 +1,186 @@
+package soytrie
+
+import (
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"sync"
+)
+
+// SyncTrie is a Trie safe for concurrent use by multiple goroutines.
+// Reads share a read lock, while mutations take the write lock.
+type SyncTrie[K comparable, V any] struct {
+	mut  sync.RWMutex
+	trie *Trie[K, V]
+}
+
+func NewSyncTrie[K comparable, V any]() *SyncTrie[K, V] {
+	return &SyncTrie[K, V]{trie: NewTrie[K, V]()}
+}
+
+func (s *SyncTrie[K, V]) Len() int {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.trie.Len()
+}
+
+func (s *SyncTrie[K, V]) NodeCount() int {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.trie.NodeCount()
+}
+
+func (s *SyncTrie[K, V]) SetOrder(order *Order[K]) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.trie.SetOrder(order)
+}
+
+func (s *SyncTrie[K, V]) Insert(v V, p0 K, pRest ...K) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.trie.Insert(v, p0, pRest...)
+}
+
+func (s *SyncTrie[K, V]) InsertStrict(v V, p0 K, pRest ...K) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	return s.trie.InsertStrict(v, p0, pRest...)
+}
+
+func (s *SyncTrie[K, V]) InsertNoOverwrite(v V, p0 K, pRest ...K) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	return s.trie.InsertNoOverwrite(v, p0, pRest...)
+}
+
+func (s *SyncTrie[K, V]) Get(path ...K) (V, bool) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.trie.Get(path...)
+}
+
+func (s *SyncTrie[K, V]) Search(mode Mode, path ...K) bool {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.trie.Search(mode, path...)
+}
+
+func (s *SyncTrie[K, V]) Predict(mode Mode, path ...K) ([]Entry[K, V], bool) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.trie.Predict(mode, path...)
+}
+
+func (s *SyncTrie[K, V]) Unique(path ...K) bool {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.trie.Unique(path...)
+}
+
+func (s *SyncTrie[K, V]) Remove(path ...K) (int, bool) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	return s.trie.Remove(path...)
+}
+
+func (s *SyncTrie[K, V]) Delete(path ...K) (V, bool) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	return s.trie.Delete(path...)
+}
+
+// WithPrefix returns an iterator over a consistent snapshot
+// of the entries whose paths start with path. The snapshot is taken
+// when iteration starts, so the loop body may safely call other methods on s.
+func (s *SyncTrie[K, V]) WithPrefix(path ...K) iter.Seq2[[]K, V] {
+	return func(yield func([]K, V) bool) {
+		var entries []Entry[K, V]
+		s.mut.RLock()
+		for p, v := range s.trie.WithPrefix(path...) {
+			entries = append(entries, Entry[K, V]{Path: slices.Clone(p), Value: v, Valued: true})
+		}
+		s.mut.RUnlock()
+
+		for i := range entries {
+			if !yield(entries[i].Path, entries[i].Value) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over a consistent snapshot of all entries.
+// See WithPrefix.
+func (s *SyncTrie[K, V]) Values() iter.Seq2[[]K, V] {
+	return s.WithPrefix()
+}
+
+func (s *SyncTrie[K, V]) CountPrefix(path ...K) int {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.trie.CountPrefix(path...)
+}
+
+func (s *SyncTrie[K, V]) Rank(path ...K) (int, bool) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.trie.Rank(path...)
+}
+
+func (s *SyncTrie[K, V]) Select(i int) (Entry[K, V], bool) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.trie.Select(i)
+}
+
+// RandomEntry returns an entry chosen uniformly at random.
+// The caller must not share rng between goroutines.
+func (s *SyncTrie[K, V]) RandomEntry(rng *rand.Rand) (Entry[K, V], bool) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.trie.RandomEntry(rng)
+}
+
+// LoadOrStore returns the existing value at p0+pRest if present.
+// Otherwise, it stores and returns v.
+// The loaded result is true if the value was loaded, false if stored.
+func (s *SyncTrie[K, V]) LoadOrStore(v V, p0 K, pRest ...K) (V, bool) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	node, created := s.trie.root.getOrInsertPath(p0, pRest)
+	s.trie.grew(p0, pRest, created, !node.Valued)
+	if node.Valued {
+		return node.Value, true
+	}
+	node.Valued, node.Value = true, v
+	return v, false
+}
+
+// CompareAndSwap swaps the value at p0+pRest to newV
+// if the existing value is equal to old.
+// Like sync.Map, it panics if V is not comparable.
+func (s *SyncTrie[K, V]) CompareAndSwap(old, newV V, p0 K, pRest ...K) bool {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	node, ok := s.trie.root.Get(append([]K{p0}, pRest...)...)
+	if !ok || !node.Valued || any(node.Value) != any(old) {
+		return false
+	}
+	node.Value = newV
+	return true
+}
+
+// CompareAndDelete deletes the value at path
+// if the existing value is equal to old.
+// Like sync.Map, it panics if V is not comparable.
+func (s *SyncTrie[K, V]) CompareAndDelete(old V, path ...K) bool {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	node, ok := s.trie.root.Get(path...)
+	if !ok || !node.Valued || any(node.Value) != any(old) {
+		return false
+	}
+	_, deleted := s.trie.Delete(path...)
+	return deleted
+}
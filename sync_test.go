@@ -0,0 +1,119 @@
+package soytrie_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func TestSyncTrie(t *testing.T) {
+	t.Run("concurrent insert and predict", func(t *testing.T) {
+		trie := soytrie.NewSyncTrie[int, int]()
+		workers, perWorker := 8, 100
+
+		var wg sync.WaitGroup
+		for w := range workers {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for i := range perWorker {
+					trie.Insert(i, w, i/10, i)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for i := range perWorker {
+					_, _ = trie.Predict(soytrie.ModeExact, w)
+					_ = trie.Search(soytrie.ModePrefix, w, i/10)
+					for range trie.WithPrefix(w) {
+						_, _ = trie.Get(w, i/10, i)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		if l := trie.Len(); l != workers*perWorker {
+			t.Fatalf("unexpected Len %d", l)
+		}
+	})
+
+	t.Run("LoadOrStore", func(t *testing.T) {
+		trie := soytrie.NewSyncTrie[string, int]()
+		workers := 16
+		stored := make([]bool, workers)
+
+		var wg sync.WaitGroup
+		for w := range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, loaded := trie.LoadOrStore(w, "a", "b")
+				stored[w] = !loaded
+			}()
+		}
+		wg.Wait()
+
+		count := 0
+		for _, s := range stored {
+			if s {
+				count++
+			}
+		}
+		if count != 1 {
+			t.Fatalf("unexpected number of stores %d", count)
+		}
+		if l := trie.Len(); l != 1 {
+			t.Fatalf("unexpected Len %d", l)
+		}
+	})
+
+	t.Run("CompareAndSwap", func(t *testing.T) {
+		trie := soytrie.NewSyncTrie[string, int]()
+		trie.Insert(0, "counter")
+		workers, perWorker := 8, 100
+
+		var wg sync.WaitGroup
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range perWorker {
+					for {
+						v, _ := trie.Get("counter")
+						if trie.CompareAndSwap(v, v+1, "counter") {
+							break
+						}
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		v, ok := trie.Get("counter")
+		if !ok || v != workers*perWorker {
+			t.Fatalf("unexpected counter %d", v)
+		}
+		if trie.CompareAndSwap(0, 1, "missing") {
+			t.Fatal("unexpected swap on missing path")
+		}
+	})
+
+	t.Run("CompareAndDelete", func(t *testing.T) {
+		trie := soytrie.NewSyncTrie[string, int]()
+		trie.Insert(1, "a", "b")
+		if trie.CompareAndDelete(2, "a", "b") {
+			t.Fatal("unexpected delete with wrong old value")
+		}
+		if !trie.CompareAndDelete(1, "a", "b") {
+			t.Fatal("unexpected false")
+		}
+		if trie.Len() != 0 || trie.NodeCount() != 0 {
+			t.Fatalf("unexpected counts %d %d", trie.Len(), trie.NodeCount())
+		}
+		if trie.CompareAndDelete(1, "a", "b") {
+			t.Fatal("unexpected delete on missing path")
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package soytrie
+
+import (
+	"slices"
+	"strings"
+)
+
+const (
+	TopicSingleLevel = "+"
+	TopicMultiLevel  = "#"
+)
+
+// Match returns every valued node in n whose path, read as an MQTT
+// topic filter, matches topic. Entries carry the matching filters.
+//
+// Matching follows MQTT 3.1.1 and 5: "+" matches exactly one level,
+// and a trailing "#" matches the parent level and any number of
+// child levels. Filters starting with a wildcard do not match topics
+// whose first level starts with "$". Topics containing wildcards,
+// and empty topics, match nothing.
+func Match[V any](n *Node[string, V], topic ...string) []Entry[string, V] {
+	if len(topic) == 0 {
+		return nil
+	}
+	for _, level := range topic {
+		if strings.ContainsAny(level, TopicSingleLevel+TopicMultiLevel) {
+			return nil
+		}
+	}
+
+	entries := []Entry[string, V]{}
+	matchTopic(n, topic, nil, &entries)
+	return entries
+}
+
+func matchTopic[V any](
+	node *Node[string, V],
+	topic []string,
+	filter []string,
+	entries *[]Entry[string, V],
+) {
+	if len(topic) == 0 {
+		appendEntry(node, filter, entries)
+		if multi, ok := node.GetDirect(TopicMultiLevel); ok {
+			appendEntry(multi, append(filter, TopicMultiLevel), entries)
+		}
+		return
+	}
+
+	level := topic[0]
+	if next, ok := node.GetDirect(level); ok {
+		matchTopic(next, topic[1:], append(filter, level), entries)
+	}
+
+	// Wildcards at the first level do not match $-prefixed topics
+	if len(filter) == 0 && strings.HasPrefix(level, "$") {
+		return
+	}
+	if single, ok := node.GetDirect(TopicSingleLevel); ok {
+		matchTopic(single, topic[1:], append(filter, TopicSingleLevel), entries)
+	}
+	if multi, ok := node.GetDirect(TopicMultiLevel); ok {
+		appendEntry(multi, append(filter, TopicMultiLevel), entries)
+	}
+}
+
+func appendEntry[K comparable, V any](node *Node[K, V], path []K, entries *[]Entry[K, V]) {
+	if !node.Valued {
+		return
+	}
+	*entries = append(*entries, Entry[K, V]{
+		Path:   slices.Clone(path),
+		Value:  node.Value,
+		Valued: true,
+	})
+}
@@ -0,0 +1,105 @@
+package soytrie_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func TestMatch(t *testing.T) {
+	root := soytrie.New[string, []string]()
+	filters := []string{
+		"sport/tennis/player1",
+		"sport/tennis/player1/#",
+		"sport/tennis/+",
+		"sport/#",
+		"sport/+",
+		"+/+",
+		"/+",
+		"+",
+		"#",
+		"+/tennis/#",
+		"$SYS/#",
+		"$SYS/monitor/+",
+		"finance",
+	}
+	for _, f := range filters {
+		levels := strings.Split(f, "/")
+		_ = root.Insert([]string{"sub:" + f}, levels[0], levels[1:]...)
+	}
+
+	type testCase struct {
+		topic    string
+		expected []string
+	}
+
+	tests := []testCase{
+		{
+			topic: "sport/tennis/player1",
+			expected: []string{
+				"#", "+/tennis/#", "sport/#", "sport/tennis/+",
+				"sport/tennis/player1", "sport/tennis/player1/#",
+			},
+		},
+		{
+			topic:    "sport/tennis/player1/ranking",
+			expected: []string{"#", "+/tennis/#", "sport/#", "sport/tennis/player1/#"},
+		},
+		{
+			topic:    "sport",
+			expected: []string{"#", "+", "sport/#"},
+		},
+		{
+			topic:    "sport/",
+			expected: []string{"#", "+/+", "sport/#", "sport/+"},
+		},
+		{
+			topic:    "/finance",
+			expected: []string{"#", "+/+", "/+"},
+		},
+		{
+			topic:    "finance",
+			expected: []string{"#", "+", "finance"},
+		},
+		{
+			topic:    "$SYS/monitor/Clients",
+			expected: []string{"$SYS/#", "$SYS/monitor/+"},
+		},
+		{
+			topic:    "$SYS",
+			expected: []string{"$SYS/#"},
+		},
+		{
+			topic:    "$other/x",
+			expected: []string{},
+		},
+		{
+			topic:    "sport/+",
+			expected: nil,
+		},
+	}
+
+	for i := range tests {
+		tc := &tests[i]
+		levels := strings.Split(tc.topic, "/")
+		entries := soytrie.Match(root, levels...)
+
+		actual := []string{}
+		for _, e := range entries {
+			if filter := strings.Join(e.Path, "/"); e.Value[0] != "sub:"+filter {
+				t.Fatalf("[case %d] unexpected value %v for filter %s", i, e.Value, filter)
+			}
+			actual = append(actual, strings.Join(e.Path, "/"))
+		}
+		slices.Sort(actual)
+		expected := tc.expected
+		if expected == nil {
+			expected = []string{}
+		}
+		if !slices.Equal(expected, actual) {
+			t.Fatalf("[case %d] unexpected filters %v for topic %s, expecting %v", i, actual, tc.topic, expected)
+		}
+	}
+}
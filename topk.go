@@ -0,0 +1,204 @@
+package soytrie
+
+import (
+	"container/heap"
+	"math"
+	"slices"
+)
+
+// WeightedTrie is a trie of weighted entries that can answer
+// top-k queries without scanning whole subtrees.
+//
+// Every node keeps the maximum weight found in its subtree,
+// which is updated along the modified path on every mutation.
+type WeightedTrie[K comparable, V any] struct {
+	root *Node[K, weighted[V]]
+}
+
+// WeightedEntry is an entry with its weight
+type WeightedEntry[K comparable, V any] struct {
+	Entry[K, V]
+	Weight float64
+}
+
+// weighted is the value stored in every node, valued or not
+type weighted[V any] struct {
+	value  V
+	weight float64
+	best   float64 // max weight in subtree, -Inf if none
+}
+
+func NewWeightedTrie[K comparable, V any]() *WeightedTrie[K, V] {
+	return &WeightedTrie[K, V]{root: newWeightedNode[K, V]()}
+}
+
+func newWeightedNode[K comparable, V any]() *Node[K, weighted[V]] {
+	return &Node[K, weighted[V]]{Value: weighted[V]{best: math.Inf(-1)}}
+}
+
+// SetOrder sets the order in which entries of equal weight are
+// returned by TopK. See Node.SetOrder.
+func (t *WeightedTrie[K, V]) SetOrder(order *Order[K]) {
+	t.root.SetOrder(order)
+}
+
+// Insert inserts v with weight to p0+pRest, overwriting
+// any existing value and weight
+func (t *WeightedTrie[K, V]) Insert(v V, weight float64, p0 K, pRest ...K) {
+	path := append([]K{p0}, pRest...)
+	nodes := make([]*Node[K, weighted[V]], 0, len(path)+1)
+	nodes = append(nodes, t.root)
+	curr := t.root
+	for i := range path {
+		curr, _ = curr.GetOrInsertDirect(path[i], newWeightedNode[K, V]())
+		nodes = append(nodes, curr)
+	}
+	curr.Valued = true
+	curr.Value.value, curr.Value.weight = v, weight
+	updateBest(nodes)
+}
+
+// Get returns the value and weight at path, if any
+func (t *WeightedTrie[K, V]) Get(path ...K) (V, float64, bool) {
+	node, ok := t.root.Get(path...)
+	if !ok || !node.Valued {
+		var zero V
+		return zero, 0, false
+	}
+	return node.Value.value, node.Value.weight, true
+}
+
+// Remove removes the subtree at path
+func (t *WeightedTrie[K, V]) Remove(path ...K) bool {
+	if _, ok := t.root.Remove(path...); !ok {
+		return false
+	}
+	updateBest(t.ancestors(path[:len(path)-1]))
+	return true
+}
+
+// Delete clears the value at path and prunes empty nodes.
+// See Node.Delete.
+func (t *WeightedTrie[K, V]) Delete(path ...K) (V, float64, bool) {
+	old, _, ok := t.root.deletePrune(path)
+	if !ok {
+		var zero V
+		return zero, 0, false
+	}
+	updateBest(t.ancestors(path))
+	return old.value, old.weight, true
+}
+
+// ancestors returns the nodes along path that still exist
+func (t *WeightedTrie[K, V]) ancestors(path []K) []*Node[K, weighted[V]] {
+	nodes := []*Node[K, weighted[V]]{t.root}
+	curr := t.root
+	for i := range path {
+		next, ok := curr.GetDirect(path[i])
+		if !ok {
+			break
+		}
+		nodes = append(nodes, next)
+		curr = next
+	}
+	return nodes
+}
+
+// updateBest recomputes the subtree max weights of nodes,
+// which must be a path from the root, from the deepest up
+func updateBest[K comparable, V any](nodes []*Node[K, weighted[V]]) {
+	for i := len(nodes) - 1; i >= 0; i-- {
+		node := nodes[i]
+		best := math.Inf(-1)
+		if node.Valued {
+			best = node.Value.weight
+		}
+		for _, child := range node.Children {
+			best = max(best, child.Value.best)
+		}
+		node.Value.best = best
+	}
+}
+
+// TopK returns up to k entries under prefix with the highest weights,
+// in descending order of weight. Entries of equal weight are returned
+// in pre-order, visiting children in the order set with SetOrder.
+//
+// Subtrees are explored best-first using their max weights, so only
+// the nodes leading to the results (and their siblings) are visited.
+func (t *WeightedTrie[K, V]) TopK(k int, prefix ...K) []WeightedEntry[K, V] {
+	target, ok := t.root.Get(prefix...)
+	if !ok || k <= 0 {
+		return nil
+	}
+
+	results := make([]WeightedEntry[K, V], 0, k)
+	q := &topKQueue[K, V]{}
+	heap.Push(q, topKItem[K, V]{node: target, path: slices.Clone(prefix), priority: target.Value.best})
+	for q.Len() != 0 && len(results) < k {
+		item := heap.Pop(q).(topKItem[K, V])
+		if math.IsInf(item.priority, -1) {
+			break
+		}
+		if item.entry {
+			results = append(results, WeightedEntry[K, V]{
+				Entry: Entry[K, V]{
+					Path:   item.path,
+					Value:  item.node.Value.value,
+					Valued: true,
+				},
+				Weight: item.priority,
+			})
+			continue
+		}
+
+		if item.node.Valued {
+			heap.Push(q, topKItem[K, V]{node: item.node, path: item.path, pos: item.pos, priority: item.node.Value.weight, entry: true})
+		}
+		i := 0
+		for key, child := range item.node.children() {
+			path := append(slices.Clip(item.path), key)
+			pos := append(slices.Clip(item.pos), i)
+			heap.Push(q, topKItem[K, V]{node: child, path: path, pos: pos, priority: child.Value.best})
+			i++
+		}
+	}
+	return results
+}
+
+// topKItem is either a subtree (prioritized by its max weight)
+// or a single entry (prioritized by its weight)
+type topKItem[K comparable, V any] struct {
+	node     *Node[K, weighted[V]]
+	path     []K
+	pos      []int // indices of the children along path, for ties
+	priority float64
+	entry    bool
+}
+
+type topKQueue[K comparable, V any] []topKItem[K, V]
+
+func (q topKQueue[K, V]) Len() int { return len(q) }
+
+// Less orders by priority, then in pre-order. An entry comes before
+// the subtrees below it, since its pos is a prefix of theirs.
+func (q topKQueue[K, V]) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	if c := slices.Compare(q[i].pos, q[j].pos); c != 0 {
+		return c < 0
+	}
+	return q[i].entry && !q[j].entry
+}
+
+func (q topKQueue[K, V]) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *topKQueue[K, V]) Push(x any) { *q = append(*q, x.(topKItem[K, V])) }
+
+func (q *topKQueue[K, V]) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,127 @@
+package soytrie_test
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func TestTopK(t *testing.T) {
+	trie := soytrie.NewWeightedTrie[rune, string]()
+	words := map[string]float64{
+		"car":    10,
+		"cart":   3,
+		"care":   8,
+		"career": 9,
+		"cat":    20,
+		"dog":    15,
+		"do":     1,
+	}
+	for w, weight := range words {
+		r := []rune(w)
+		trie.Insert(w, weight, r[0], r[1:]...)
+	}
+
+	assertTopK := func(t *testing.T, k int, prefix string, expected ...string) {
+		t.Helper()
+		actual := []string{}
+		for _, e := range trie.TopK(k, []rune(prefix)...) {
+			if string(e.Path) != e.Value || words[e.Value] != e.Weight {
+				t.Fatalf("unexpected entry %+v", e)
+			}
+			actual = append(actual, e.Value)
+		}
+		if !slices.Equal(expected, actual) {
+			t.Fatalf("unexpected TopK(%d, %s) %v, expecting %v", k, prefix, actual, expected)
+		}
+	}
+
+	assertTopK(t, 3, "", "cat", "dog", "car")
+	assertTopK(t, 3, "car", "car", "career", "care")
+	assertTopK(t, 10, "car", "car", "career", "care", "cart")
+	assertTopK(t, 1, "d", "dog")
+	assertTopK(t, 0, "d")
+	assertTopK(t, 1, "x")
+
+	// Lowering the weight of cat must update its ancestors
+	words["cat"] = 2
+	trie.Insert("cat", 2, 'c', 'a', 't')
+	assertTopK(t, 2, "", "dog", "car")
+	assertTopK(t, 2, "ca", "car", "career")
+
+	if _, _, ok := trie.Delete('c', 'a', 'r'); !ok {
+		t.Fatal("unexpected false")
+	}
+	delete(words, "car")
+	assertTopK(t, 2, "ca", "career", "care")
+
+	if !trie.Remove('d') {
+		t.Fatal("unexpected false")
+	}
+	assertTopK(t, 2, "", "career", "care")
+
+	v, weight, ok := trie.Get('c', 'a', 'r', 't')
+	if !ok || v != "cart" || weight != 3 {
+		t.Fatalf("unexpected Get result %s %v %v", v, weight, ok)
+	}
+}
+
+func TestTopKRandom(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	trie := soytrie.NewWeightedTrie[byte, string]()
+	weights := map[string]float64{}
+	for range 500 {
+		b := make([]byte, 1+rng.IntN(6))
+		for i := range b {
+			b[i] = 'a' + byte(rng.IntN(4))
+		}
+		w := rng.Float64()
+		weights[string(b)] = w
+		trie.Insert(string(b), w, b[0], b[1:]...)
+	}
+
+	for _, prefix := range []string{"", "a", "ab", "dcb"} {
+		expected := []string{}
+		for w := range weights {
+			if strings.HasPrefix(w, prefix) {
+				expected = append(expected, w)
+			}
+		}
+		slices.SortFunc(expected, func(a, b string) int {
+			return cmp.Compare(weights[b], weights[a])
+		})
+		expected = expected[:min(10, len(expected))]
+
+		actual := []string{}
+		for _, e := range trie.TopK(10, []byte(prefix)...) {
+			actual = append(actual, e.Value)
+		}
+		if !slices.Equal(expected, actual) {
+			t.Fatalf("unexpected TopK for prefix %s: %v, expecting %v", prefix, actual, expected)
+		}
+	}
+}
+
+func TestTopKTies(t *testing.T) {
+	trie := soytrie.NewWeightedTrie[string, string]()
+	trie.SetOrder(soytrie.OrderAscending[string]())
+	for _, path := range [][]string{{"b"}, {"a", "z"}, {"c"}, {"a"}, {"b", "a"}} {
+		trie.Insert(strings.Join(path, "."), 1, path[0], path[1:]...)
+	}
+	trie.Insert("d", 2, "d")
+
+	expected := []string{"d", "a", "a.z", "b", "b.a", "c"}
+	for range 10 {
+		actual := []string{}
+		for _, e := range trie.TopK(10) {
+			actual = append(actual, e.Value)
+		}
+		if !slices.Equal(actual, expected) {
+			t.Fatalf("unexpected order %v, expected %v", actual, expected)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package soytrie
+
+import (
+	"fmt"
+	"iter"
+)
+
+// Trie wraps a root node and keeps track of its size.
+// Unlike Node, Trie does not expose its nodes, so callers
+// cannot break its invariants by mutating them directly.
+//
+// Every node keeps the number of entries and nodes in its subtree,
+// updated along the modified path on every mutation.
+type Trie[K comparable, V any] struct {
+	root *Node[K, V]
+}
+
+func NewTrie[K comparable, V any]() *Trie[K, V] {
+	return &Trie[K, V]{root: New[K, V]()}
+}
+
+// Len returns the number of valued entries in t
+func (t *Trie[K, V]) Len() int {
+	return t.root.count
+}
+
+// NodeCount returns the number of nodes in t, excluding the root
+func (t *Trie[K, V]) NodeCount() int {
+	return t.root.nodes
+}
+
+// SetOrder sets the order in which t's entries are visited.
+// See Node.SetOrder.
+func (t *Trie[K, V]) SetOrder(order *Order[K]) {
+	t.root.SetOrder(order)
+}
+
+func (t *Trie[K, V]) Insert(v V, p0 K, pRest ...K) {
+	node, created := t.root.getOrInsertPath(p0, pRest)
+	t.grew(p0, pRest, created, !node.Valued)
+	node.Valued, node.Value = true, v
+}
+
+// InsertStrict inserts v to p0+pRest if and only if
+// the insertion would create a new node
+func (t *Trie[K, V]) InsertStrict(v V, p0 K, pRest ...K) error {
+	_, err := t.root.InsertStrict(v, p0, pRest...)
+	if err != nil {
+		return err
+	}
+	t.grew(p0, pRest, 1, true)
+	return nil
+}
+
+// InsertNoOverwrite inserts v to p0+pRest only if
+// the insertion to p0+pRest does not overwrite existing value
+func (t *Trie[K, V]) InsertNoOverwrite(v V, p0 K, pRest ...K) error {
+	node, created := t.root.getOrInsertPath(p0, pRest)
+	t.grew(p0, pRest, created, !node.Valued)
+	if node.Valued {
+		return fmt.Errorf("valued node exists: %v", node.Value)
+	}
+	node.Valued, node.Value = true, v
+	return nil
+}
+
+// Get returns the value at path, if any
+func (t *Trie[K, V]) Get(path ...K) (V, bool) {
+	node, ok := t.root.Get(path...)
+	if !ok || !node.Valued {
+		var zero V
+		return zero, false
+	}
+	return node.Value, true
+}
+
+func (t *Trie[K, V]) Search(mode Mode, path ...K) bool {
+	return t.root.Search(mode, path...)
+}
+
+// Predict returns entries under path with their full paths.
+// See Node.Predict for the meaning of mode.
+func (t *Trie[K, V]) Predict(mode Mode, path ...K) ([]Entry[K, V], bool) {
+	return t.root.PredictPaths(mode, 0, path...)
+}
+
+// Unique returns whether the path is a unique path
+// or a prefix to a valued node. Unlike Node.Unique, it does not
+// visit the subtree, so like CountPrefix it is O(depth).
+func (t *Trie[K, V]) Unique(path ...K) bool {
+	return t.CountPrefix(path...) == 1
+}
+
+// Remove removes the whole subtree at path,
+// and returns the number of valued entries removed.
+func (t *Trie[K, V]) Remove(path ...K) (int, bool) {
+	removed, ok := t.root.Remove(path...)
+	if !ok {
+		return 0, false
+	}
+	valued := removed.count
+	t.root.shrink(valued, removed.nodes+1, path[:len(path)-1])
+	return valued, true
+}
+
+// Delete clears the value at path and prunes empty nodes.
+// See Node.Delete.
+func (t *Trie[K, V]) Delete(path ...K) (V, bool) {
+	old, pruned, ok := t.root.deletePrune(path)
+	if !ok {
+		return old, false
+	}
+	// Pruned nodes are gone, so only the remaining ancestors are updated
+	t.root.shrink(1, pruned, path)
+	return old, true
+}
+
+// Values returns an iterator over all entries in t.
+//
+// The yielded path is only valid until the next iteration.
+func (t *Trie[K, V]) Values() iter.Seq2[[]K, V] {
+	return t.WithPrefix()
+}
+
+// WithPrefix returns an iterator over all entries
+// whose paths start with path.
+//
+// The yielded path is only valid until the next iteration.
+func (t *Trie[K, V]) WithPrefix(path ...K) iter.Seq2[[]K, V] {
+	return func(yield func([]K, V) bool) {
+		for p, node := range t.root.WithPrefix(path...) {
+			if !yield(p, node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// getOrInsertPath walks p0+pRest from n, creating missing nodes,
+// and returns the last node and the number of nodes created.
+func (n *Node[K, V]) getOrInsertPath(p0 K, pRest []K) (*Node[K, V], int) {
+	created := 0
+	curr, existed := n.GetOrInsertDirect(p0, New[K, V]())
+	if !existed {
+		created++
+	}
+	for i := range pRest {
+		curr, existed = curr.GetOrInsertDirect(pRest[i], New[K, V]())
+		if !existed {
+			created++
+		}
+	}
+	return curr, created
+}
@@ -0,0 +1,118 @@
+package soytrie_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func TestTrie(t *testing.T) {
+	trie := soytrie.NewTrie[int, string]()
+
+	assertCounts := func(t *testing.T, size, nodes int) {
+		t.Helper()
+		if l := trie.Len(); l != size {
+			t.Fatalf("unexpected Len %d, expecting %d", l, size)
+		}
+		if c := trie.NodeCount(); c != nodes {
+			t.Fatalf("unexpected NodeCount %d, expecting %d", c, nodes)
+		}
+	}
+
+	trie.Insert("1,2,3", 1, 2, 3)
+	assertCounts(t, 1, 3)
+	trie.Insert("1,2", 1, 2)
+	assertCounts(t, 2, 3)
+	trie.Insert("new_1,2", 1, 2) // overwrite
+	assertCounts(t, 2, 3)
+	trie.Insert("1,5", 1, 5)
+	assertCounts(t, 3, 4)
+
+	if err := trie.InsertStrict("1,5", 1, 5); err == nil {
+		t.Fatal("unexpected nil error")
+	}
+	if err := trie.InsertStrict("1,5,6,7", 1, 5, 6, 7); err == nil {
+		t.Fatal("unexpected nil error")
+	}
+	if err := trie.InsertStrict("1,5,6", 1, 5, 6); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	assertCounts(t, 4, 5)
+
+	if err := trie.InsertNoOverwrite("1,2", 1, 2); err == nil {
+		t.Fatal("unexpected nil error")
+	}
+	if err := trie.InsertNoOverwrite("1", 1); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	assertCounts(t, 5, 5)
+	if err := trie.InsertNoOverwrite("0,2", 0, 2); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	assertCounts(t, 6, 7)
+
+	v, ok := trie.Get(1, 2)
+	if !ok || v != "new_1,2" {
+		t.Fatalf("unexpected Get result '%s' %v", v, ok)
+	}
+	if _, ok := trie.Get(0); ok {
+		t.Fatal("unexpected ok for non-valued node")
+	}
+	if !trie.Search(soytrie.ModePrefix, 0) {
+		t.Fatal("unexpected false")
+	}
+	if trie.Unique(1) {
+		t.Fatal("unexpected true")
+	}
+	if !trie.Unique(1, 5, 6) {
+		t.Fatal("unexpected false")
+	}
+
+	entries, ok := trie.Predict(soytrie.ModeExact, 1)
+	if !ok {
+		t.Fatal("unexpected false")
+	}
+	if l := len(entries); l != 5 {
+		t.Fatalf("unexpected Predict length %d", l)
+	}
+
+	values := []string{}
+	for _, v := range trie.Values() {
+		values = append(values, v)
+	}
+	if l := len(values); l != trie.Len() {
+		t.Fatalf("unexpected number of values %d", l)
+	}
+
+	// Remove 1,5 (1,5 and 1,5,6)
+	removed, ok := trie.Remove(1, 5)
+	if !ok || removed != 2 {
+		t.Fatalf("unexpected Remove result %d %v", removed, ok)
+	}
+	assertCounts(t, 4, 5)
+	if _, ok := trie.Remove(1, 5); ok {
+		t.Fatal("unexpected true")
+	}
+
+	// Delete 0,2 prunes 0,2 and 0
+	old, ok := trie.Delete(0, 2)
+	if !ok || old != "0,2" {
+		t.Fatalf("unexpected Delete result '%s' %v", old, ok)
+	}
+	assertCounts(t, 3, 3)
+
+	// Delete 1,2 prunes nothing
+	if _, ok := trie.Delete(1, 2); !ok {
+		t.Fatal("unexpected false")
+	}
+	assertCounts(t, 2, 3)
+
+	paths := [][]int{}
+	for path := range trie.WithPrefix(1) {
+		paths = append(paths, slices.Clone(path))
+	}
+	if l := len(paths); l != 2 {
+		t.Fatalf("unexpected number of paths %d", l)
+	}
+}
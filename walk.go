@@ -0,0 +1,150 @@
+package soytrie
+
+import "slices"
+
+// WalkOrder is the order in which Walk visits nodes
+type WalkOrder uint8
+
+const (
+	// WalkPreOrder visits nodes before their children
+	WalkPreOrder WalkOrder = iota
+
+	// WalkPostOrder visits nodes after their children
+	WalkPostOrder
+
+	// WalkLevelOrder visits nodes breadth-first, level by level
+	WalkLevelOrder
+)
+
+// WalkAction tells Walk how to continue after visiting a node
+type WalkAction uint8
+
+const (
+	WalkContinue WalkAction = iota
+
+	// WalkSkipChildren skips the visited node's children.
+	// It has no effect in WalkPostOrder, where children
+	// are visited first.
+	WalkSkipChildren
+
+	// WalkStop stops the walk
+	WalkStop
+)
+
+// Walk visits every node under n (including n itself) in order.
+// Walk is iterative, so very deep tries cannot overflow the stack.
+//
+// The path passed to visit is only valid until visit returns.
+func (n *Node[K, V]) Walk(order WalkOrder, visit func(path []K, node *Node[K, V]) WalkAction) {
+	switch order {
+	case WalkPreOrder:
+		walkPreOrder(n, visit)
+	case WalkPostOrder:
+		walkPostOrder(n, visit)
+	case WalkLevelOrder:
+		walkLevelOrder(n, visit)
+	}
+}
+
+type walkFrame[K comparable, V any] struct {
+	node     *Node[K, V]
+	key      K
+	depth    int
+	expanded bool
+}
+
+// In depth-first walks, frames are popped after all frames
+// pushed above them, so path[:depth-1] always holds the
+// popped frame's parent path, and we can share one buffer.
+
+func walkPreOrder[K comparable, V any](n *Node[K, V], visit func([]K, *Node[K, V]) WalkAction) {
+	path := []K{}
+	stack := []walkFrame[K, V]{{node: n}}
+	for len(stack) != 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if f.depth != 0 {
+			path = append(path[:f.depth-1], f.key)
+		}
+
+		switch visit(path[:f.depth:f.depth], f.node) {
+		case WalkStop:
+			return
+		case WalkSkipChildren:
+			continue
+		}
+		// Push children in reverse, so that they are popped in order
+		start := len(stack)
+		for k, child := range f.node.children() {
+			stack = append(stack, walkFrame[K, V]{node: child, key: k, depth: f.depth + 1})
+		}
+		slices.Reverse(stack[start:])
+	}
+}
+
+func walkPostOrder[K comparable, V any](n *Node[K, V], visit func([]K, *Node[K, V]) WalkAction) {
+	path := []K{}
+	stack := []walkFrame[K, V]{{node: n}}
+	for len(stack) != 0 {
+		f := &stack[len(stack)-1]
+		if f.depth != 0 {
+			path = append(path[:f.depth-1], f.key)
+		}
+		if !f.expanded {
+			f.expanded = true
+			node, depth := f.node, f.depth
+			start := len(stack)
+			for k, child := range node.children() {
+				stack = append(stack, walkFrame[K, V]{node: child, key: k, depth: depth + 1})
+			}
+			slices.Reverse(stack[start:])
+			continue
+		}
+
+		stack = stack[:len(stack)-1]
+		if visit(path[:f.depth:f.depth], f.node) == WalkStop {
+			return
+		}
+	}
+}
+
+// levelFrame links to its parent frame, so that paths
+// can be rebuilt without storing a copy in every frame
+type levelFrame[K comparable, V any] struct {
+	node   *Node[K, V]
+	key    K
+	depth  int
+	parent *levelFrame[K, V]
+}
+
+func walkLevelOrder[K comparable, V any](n *Node[K, V], visit func([]K, *Node[K, V]) WalkAction) {
+	// path holds the keys of the frames in onPath, so we only
+	// rebuild the part of path below the last common ancestor
+	path := []K{}
+	onPath := []*levelFrame[K, V]{}
+	queue := []*levelFrame[K, V]{{node: n}}
+	for len(queue) != 0 {
+		f := queue[0]
+		queue[0] = nil
+		queue = queue[1:]
+
+		if f.depth > len(onPath) {
+			path = append(path, make([]K, f.depth-len(path))...)
+			onPath = append(onPath, make([]*levelFrame[K, V], f.depth-len(onPath))...)
+		}
+		path, onPath = path[:f.depth], onPath[:f.depth]
+		for p := f; p.depth != 0 && onPath[p.depth-1] != p; p = p.parent {
+			path[p.depth-1], onPath[p.depth-1] = p.key, p
+		}
+
+		switch visit(path[:f.depth:f.depth], f.node) {
+		case WalkStop:
+			return
+		case WalkSkipChildren:
+			continue
+		}
+		for k, child := range f.node.children() {
+			queue = append(queue, &levelFrame[K, V]{node: child, key: k, depth: f.depth + 1, parent: f})
+		}
+	}
+}
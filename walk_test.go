@@ -0,0 +1,130 @@
+package soytrie_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/soyart/soytrie-go"
+)
+
+func TestWalk(t *testing.T) {
+	root := soytrie.New[int, string]()
+	_ = root.Insert("1", 1)
+	_ = root.Insert("1,2", 1, 2)
+	_ = root.Insert("1,2,3", 1, 2, 3)
+	_ = root.Insert("1,4", 1, 4)
+	_ = root.Insert("5,6,7", 5, 6, 7)
+
+	orders := []soytrie.WalkOrder{soytrie.WalkPreOrder, soytrie.WalkPostOrder, soytrie.WalkLevelOrder}
+
+	t.Run("visits every node with its path", func(t *testing.T) {
+		for _, order := range orders {
+			depths := []int{}
+			seen := map[*soytrie.Node[int, string]][]int{}
+			root.Walk(order, func(path []int, node *soytrie.Node[int, string]) soytrie.WalkAction {
+				if actual, ok := root.Get(path...); !ok || actual != node {
+					t.Fatalf("[order %d] unexpected node for path %v", order, path)
+				}
+				seen[node] = slices.Clone(path)
+				depths = append(depths, len(path))
+				return soytrie.WalkContinue
+			})
+			if l := len(seen); l != 8 {
+				t.Fatalf("[order %d] unexpected number of visited nodes %d", order, l)
+			}
+
+			for node, path := range seen {
+				for childKey, child := range node.Children {
+					childPath, ok := seen[child]
+					if !ok || !slices.Equal(childPath, append(slices.Clone(path), childKey)) {
+						t.Fatalf("[order %d] unexpected child path %v", order, childPath)
+					}
+				}
+			}
+
+			switch order {
+			case soytrie.WalkPreOrder:
+				if depths[0] != 0 {
+					t.Fatalf("unexpected pre-order depths %v", depths)
+				}
+			case soytrie.WalkPostOrder:
+				if depths[len(depths)-1] != 0 {
+					t.Fatalf("unexpected post-order depths %v", depths)
+				}
+			case soytrie.WalkLevelOrder:
+				if !slices.IsSorted(depths) {
+					t.Fatalf("unexpected level-order depths %v", depths)
+				}
+			}
+		}
+	})
+
+	t.Run("post-order visits children first", func(t *testing.T) {
+		visited := map[*soytrie.Node[int, string]]bool{}
+		root.Walk(soytrie.WalkPostOrder, func(path []int, node *soytrie.Node[int, string]) soytrie.WalkAction {
+			for _, child := range node.Children {
+				if !visited[child] {
+					t.Fatalf("unexpected visit to %v before its children", path)
+				}
+			}
+			visited[node] = true
+			return soytrie.WalkContinue
+		})
+	})
+
+	t.Run("skip children", func(t *testing.T) {
+		for _, order := range []soytrie.WalkOrder{soytrie.WalkPreOrder, soytrie.WalkLevelOrder} {
+			count := 0
+			root.Walk(order, func(path []int, _ *soytrie.Node[int, string]) soytrie.WalkAction {
+				if len(path) > 0 && path[0] == 1 && len(path) > 1 {
+					t.Fatalf("[order %d] unexpected visit to skipped subtree %v", order, path)
+				}
+				count++
+				if slices.Equal(path, []int{1}) {
+					return soytrie.WalkSkipChildren
+				}
+				return soytrie.WalkContinue
+			})
+			if count != 5 { // root, 1, 5, 5,6, 5,6,7
+				t.Fatalf("[order %d] unexpected count %d", order, count)
+			}
+		}
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		for _, order := range orders {
+			count := 0
+			root.Walk(order, func([]int, *soytrie.Node[int, string]) soytrie.WalkAction {
+				count++
+				if count == 3 {
+					return soytrie.WalkStop
+				}
+				return soytrie.WalkContinue
+			})
+			if count != 3 {
+				t.Fatalf("[order %d] unexpected count %d", order, count)
+			}
+		}
+	})
+
+	t.Run("deep path", func(t *testing.T) {
+		deep := soytrie.New[int, int]()
+		curr := deep
+		depth := 200_000
+		for i := range depth {
+			curr, _ = curr.GetOrInsertDirect(i, soytrie.New[int, int]())
+		}
+		curr.Valued = true
+
+		for _, order := range orders {
+			maxDepth := 0
+			deep.Walk(order, func(path []int, node *soytrie.Node[int, int]) soytrie.WalkAction {
+				maxDepth = max(maxDepth, len(path))
+				return soytrie.WalkContinue
+			})
+			if maxDepth != depth {
+				t.Fatalf("[order %d] unexpected max depth %d", order, maxDepth)
+			}
+		}
+	})
+}